@@ -0,0 +1,105 @@
+package gallifrey
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// StreamCoalescer merges a mostly ordered stream of intervals, emitting each
+// stretch of coverage once no interval still expected can extend it.
+//
+// The watermark trails the highest lower limit pushed so far by window
+// units. A stretch is held until it ends before the watermark, so intervals
+// arriving out of order by up to the window are merged, and only the
+// stretches within it are held in memory. An interval arriving later still
+// is merged as long as it does not reach back to coverage already emitted.
+// If it does, it is too late to merge: it is passed to Late if that is set,
+// and otherwise dropped, so that what is emitted stays merged and ascending.
+type StreamCoalescer struct {
+	Late func(Interval)
+
+	window  int64
+	emit    func(Interval)
+	pending []Interval
+	highest int64
+	emitted bool
+	last    int64
+}
+
+// NewStreamCoalescer returns a coalescer calling emit with each merged
+// interval, in ascending order, as it is settled. It panics if window is
+// negative.
+func NewStreamCoalescer(window int64, emit func(Interval)) *StreamCoalescer {
+	if window < 0 {
+		panic(fmt.Sprintf("gallifrey: negative coalescing window %d", window))
+	}
+	return &StreamCoalescer{window: window, emit: emit, highest: math.MinInt64}
+}
+
+// Push adds an interval to the stream. Intervals of zero span are ignored.
+func (c *StreamCoalescer) Push(i Interval) {
+	l, u := i.Lower(), i.Upper()
+	if l == u {
+		return
+	}
+	if l > c.highest {
+		c.highest = l
+	}
+	if c.emitted && l <= c.last {
+		if c.Late != nil {
+			c.Late(i)
+		}
+		return
+	}
+	first := sort.Search(len(c.pending), func(p int) bool {
+		return c.pending[p].Upper() >= l
+	})
+	end := first
+	for ; end < len(c.pending) && c.pending[end].Lower() <= u; end++ {
+		if c.pending[end].Lower() < l {
+			l = c.pending[end].Lower()
+		}
+		if c.pending[end].Upper() > u {
+			u = c.pending[end].Upper()
+		}
+	}
+	if end > first {
+		i = NewInterval(l, u)
+	}
+	c.pending = append(c.pending[:first], append([]Interval{i}, c.pending[end:]...)...)
+	c.release()
+}
+
+// Flush emits every interval still held, as if the stream had ended
+func (c *StreamCoalescer) Flush() {
+	c.releaseFirst(len(c.pending))
+}
+
+// Watermark returns the highest lower limit pushed so far less the window.
+// Held stretches ending before it are emitted.
+func (c *StreamCoalescer) Watermark() int64 {
+	if c.highest < math.MinInt64+c.window {
+		return math.MinInt64
+	}
+	return c.highest - c.window
+}
+
+// release emits the stretches that no interval at or past the watermark
+// can meet
+func (c *StreamCoalescer) release() {
+	watermark := c.Watermark()
+	n := 0
+	for n < len(c.pending) && c.pending[n].Upper() < watermark {
+		n++
+	}
+	c.releaseFirst(n)
+}
+
+func (c *StreamCoalescer) releaseFirst(n int) {
+	for _, i := range c.pending[:n] {
+		c.emit(i)
+		c.emitted, c.last = true, i.Upper()
+	}
+	c.pending = c.pending[n:]
+}
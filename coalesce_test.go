@@ -0,0 +1,125 @@
+package gallifrey_test
+
+import (
+	"math"
+
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamCoalescer", func() {
+
+	var (
+		coalescer *StreamCoalescer
+		emitted   []Interval
+	)
+
+	BeforeEach(func() {
+		emitted = nil
+		coalescer = NewStreamCoalescer(10, func(i Interval) {
+			emitted = append(emitted, i)
+		})
+	})
+
+	push := func(intervals ...Interval) {
+		for _, i := range intervals {
+			coalescer.Push(i)
+		}
+	}
+
+	It("merges overlapping and meeting intervals", func() {
+		push(NewInterval(0, 5), NewInterval(3, 8), NewInterval(8, 10))
+		coalescer.Flush()
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, 10)}))
+	})
+
+	It("merges intervals arriving out of order within the window", func() {
+		push(NewInterval(10, 15), NewInterval(0, 5), NewInterval(5, 10), NewInterval(20, 25))
+		coalescer.Flush()
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, 15), NewInterval(20, 25)}))
+	})
+
+	It("emits intervals once they fall behind the window", func() {
+		push(NewInterval(0, 5), NewInterval(10, 12))
+		Ω(emitted).Should(BeEmpty())
+		push(NewInterval(16, 20))
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, 5)}))
+	})
+
+	It("holds only intervals within the window", func() {
+		for l := int64(0); l < 1000; l += 2 {
+			push(NewIntervalOfSpan(l, 1))
+		}
+		Ω(emitted).Should(HaveLen(494))
+	})
+
+	It("drops intervals that arrive too late without a side output", func() {
+		push(NewInterval(0, 5), NewInterval(30, 35))
+		push(NewInterval(2, 4))
+		coalescer.Flush()
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, 5), NewInterval(30, 35)}))
+	})
+
+	It("keeps emitting in order after dropping a late interval", func() {
+		push(NewInterval(0, 5), NewInterval(30, 35), NewInterval(2, 20), NewInterval(6, 8))
+		coalescer.Flush()
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, 5), NewInterval(6, 8), NewInterval(30, 35)}))
+	})
+
+	It("routes late intervals to the side output", func() {
+		var late []Interval
+		coalescer.Late = func(i Interval) {
+			late = append(late, i)
+		}
+		push(NewInterval(0, 5), NewInterval(30, 35))
+		Ω(coalescer.Watermark()).Should(BeNumerically("==", 20))
+		push(NewInterval(12, 18), NewInterval(4, 8), NewInterval(2, 3))
+		coalescer.Flush()
+		Ω(late).Should(Equal([]Interval{NewInterval(4, 8), NewInterval(2, 3)}))
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, 5), NewInterval(12, 18), NewInterval(30, 35)}))
+	})
+
+	It("merges into a held stretch straddling the watermark", func() {
+		push(NewInterval(0, 100), NewInterval(105, 106))
+		Ω(coalescer.Watermark()).Should(BeNumerically("==", 95))
+		push(NewInterval(90, 92))
+		coalescer.Flush()
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, 100), NewInterval(105, 106)}))
+	})
+
+	It("extends a held stretch straddling the watermark instead of routing it", func() {
+		var late []Interval
+		coalescer.Late = func(i Interval) {
+			late = append(late, i)
+		}
+		push(NewInterval(0, 100), NewInterval(105, 106), NewInterval(90, 102))
+		coalescer.Flush()
+		Ω(late).Should(BeEmpty())
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, 102), NewInterval(105, 106)}))
+	})
+
+	It("advances the watermark with a contiguous stream", func() {
+		for l := int64(0); l < 100; l++ {
+			push(NewIntervalOfSpan(l, 1))
+		}
+		Ω(coalescer.Watermark()).Should(BeNumerically("==", 89))
+	})
+
+	It("emits an interval reaching the top of the int64 range on flush", func() {
+		push(NewInterval(0, math.MaxInt64))
+		coalescer.Flush()
+		Ω(emitted).Should(Equal([]Interval{NewInterval(0, math.MaxInt64)}))
+	})
+
+	It("refuses a negative window", func() {
+		Ω(func() { NewStreamCoalescer(-1, func(Interval) {}) }).Should(Panic())
+	})
+
+	It("ignores intervals of zero span", func() {
+		push(NewInterval(3, 3))
+		coalescer.Flush()
+		Ω(emitted).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,96 @@
+package gallifrey
+
+import "sort"
+
+// MinimalCover returns at most maxRanges intervals, in ascending order,
+// covering everything the given intervals cover. They may be unsorted and
+// overlap. When their coverage needs more ranges than that, the smallest
+// gaps in it are closed first, so the result covers as little extra as
+// possible. A maxRanges below one is treated as one.
+func MinimalCover(intervals []Interval, maxRanges int) []Interval {
+	merged := combine([][]Interval{intervals}, func(active []int) bool {
+		return len(active) > 0
+	})
+	cover, _ := minimalCover(merged, maxRanges)
+	return cover
+}
+
+// minimalCover merges sorted, disjoint intervals across their smallest gaps,
+// earliest first among equals, until at most maxRanges remain. It also
+// returns the total span of the gaps closed.
+func minimalCover(intervals []Interval, maxRanges int) ([]Interval, int64) {
+	if maxRanges < 1 {
+		maxRanges = 1
+	}
+	if len(intervals) <= maxRanges {
+		return append([]Interval(nil), intervals...), 0
+	}
+	gaps := make([]int, len(intervals)-1)
+	for g := range gaps {
+		gaps[g] = g
+	}
+	gap := func(g int) int64 {
+		return intervals[g+1].Lower() - intervals[g].Upper()
+	}
+	sort.SliceStable(gaps, func(a, b int) bool {
+		return gap(gaps[a]) < gap(gaps[b])
+	})
+	closed := make([]bool, len(gaps))
+	var extra int64
+	for _, g := range gaps[:len(intervals)-maxRanges] {
+		closed[g] = true
+		extra += gap(g)
+	}
+	result := make([]Interval, 0, maxRanges)
+	lower := intervals[0].Lower()
+	for g, isClosed := range closed {
+		if !isClosed {
+			result = append(result, NewInterval(lower, intervals[g].Upper()))
+			lower = intervals[g+1].Lower()
+		}
+	}
+	return append(result, NewInterval(lower, intervals[len(intervals)-1].Upper())), extra
+}
+
+// LargestNRanges returns, in ascending order, at most n of the merged ranges
+// covered by the given intervals that together cover the most, preferring
+// earlier ranges among equals. It never covers more than the intervals do;
+// the ranges left out are returned as the remainder, also in ascending
+// order. The intervals may be unsorted and overlap.
+func LargestNRanges(intervals []Interval, n int) (ranges, remainder []Interval) {
+	merged := combine([][]Interval{intervals}, func(active []int) bool {
+		return len(active) > 0
+	})
+	return largestNRanges(merged, n)
+}
+
+// largestNRanges splits sorted, disjoint intervals into the n that span the
+// most and the rest
+func largestNRanges(intervals []Interval, n int) (ranges, remainder []Interval) {
+	if n > len(intervals) {
+		n = len(intervals)
+	}
+	if n < 0 {
+		n = 0
+	}
+	order := make([]int, len(intervals))
+	for r := range order {
+		order[r] = r
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return intervals[order[a]].Span() > intervals[order[b]].Span()
+	})
+	chosen := make([]bool, len(intervals))
+	for _, r := range order[:n] {
+		chosen[r] = true
+	}
+	ranges = make([]Interval, 0, n)
+	for r, i := range intervals {
+		if chosen[r] {
+			ranges = append(ranges, i)
+		} else {
+			remainder = append(remainder, i)
+		}
+	}
+	return ranges, remainder
+}
@@ -0,0 +1,69 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MinimalCover", func() {
+
+	intervals := []Interval{
+		NewInterval(30, 40),
+		NewInterval(0, 10),
+		NewInterval(5, 12),
+		NewInterval(14, 20),
+		NewInterval(50, 60),
+	}
+
+	It("merges the input exactly when it fits", func() {
+		Ω(MinimalCover(intervals, 4)).Should(Equal([]Interval{
+			NewInterval(0, 12),
+			NewInterval(14, 20),
+			NewInterval(30, 40),
+			NewInterval(50, 60),
+		}))
+	})
+
+	It("closes the smallest gaps, earliest first, to meet the budget", func() {
+		Ω(MinimalCover(intervals, 2)).Should(Equal([]Interval{
+			NewInterval(0, 40),
+			NewInterval(50, 60),
+		}))
+		Ω(MinimalCover(intervals, 0)).Should(Equal([]Interval{NewInterval(0, 60)}))
+	})
+
+	It("covers nothing without intervals", func() {
+		Ω(MinimalCover(nil, 3)).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("LargestNRanges", func() {
+
+	intervals := []Interval{
+		NewInterval(30, 40),
+		NewInterval(0, 10),
+		NewInterval(5, 12),
+		NewInterval(14, 20),
+		NewInterval(50, 60),
+	}
+
+	It("keeps the ranges covering the most and reports the rest", func() {
+		ranges, remainder := LargestNRanges(intervals, 2)
+		Ω(ranges).Should(Equal([]Interval{NewInterval(0, 12), NewInterval(30, 40)}))
+		Ω(remainder).Should(Equal([]Interval{NewInterval(14, 20), NewInterval(50, 60)}))
+	})
+
+	It("keeps every range within the budget", func() {
+		ranges, remainder := LargestNRanges(intervals, 10)
+		Ω(ranges).Should(HaveLen(4))
+		Ω(remainder).Should(BeEmpty())
+	})
+
+	It("keeps nothing with no budget", func() {
+		ranges, remainder := LargestNRanges(intervals, -1)
+		Ω(ranges).Should(BeEmpty())
+		Ω(remainder).Should(HaveLen(4))
+	})
+})
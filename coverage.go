@@ -0,0 +1,158 @@
+package gallifrey
+
+import "sort"
+
+// Piece is a stretch of a partitioned interval that is either wholly covered
+// or wholly uncovered
+type Piece struct {
+	Interval
+	Covered bool
+}
+
+// Partition splits i into pieces, in order, that alternate between covered
+// and uncovered by the given intervals, which may be unsorted and overlap.
+// The pieces together make up exactly i, so an interval of zero span gives
+// no pieces.
+func Partition(covered []Interval, i Interval) []Piece {
+	var pieces []Piece
+	add := func(l, u int64, c bool) {
+		if l < i.Lower() {
+			l = i.Lower()
+		}
+		if u > i.Upper() {
+			u = i.Upper()
+		}
+		if l >= u {
+			return
+		}
+		if n := len(pieces) - 1; n >= 0 && pieces[n].Covered == c {
+			pieces[n].Interval = NewInterval(pieces[n].Lower(), u)
+			return
+		}
+		pieces = append(pieces, Piece{NewInterval(l, u), c})
+	}
+	from, c := i.Lower(), false
+	Sweep([][]Interval{covered}, func(x int64, active []int) {
+		if x > from {
+			add(from, x, c)
+			from = x
+		}
+		c = len(active) > 0
+	})
+	add(from, i.Upper(), c)
+	return pieces
+}
+
+// NextGap returns the first stretch of at least minLen values at or after
+// after that none of the covered intervals hold, or false if there is none.
+// The covered intervals must be sorted and not overlap, as those returned by
+// Quorum and MinimalCover are, and lie within Domain; past the last of them
+// the gap runs to the upper edge of Domain. A minLen below one is treated as
+// one. The search takes time logarithmic in the number of intervals, plus
+// one step for each gap shorter than minLen.
+func NextGap(covered []Interval, after, minLen int64) (Interval, bool) {
+	if minLen < 1 {
+		minLen = 1
+	}
+	from := after
+	if from < minLimit {
+		from = minLimit
+	}
+	c := sort.Search(len(covered), func(n int) bool {
+		return covered[n].Upper() > from
+	})
+	for ; ; c++ {
+		to := maxLimit
+		if c < len(covered) {
+			if covered[c].Lower() <= from {
+				from = covered[c].Upper()
+				continue
+			}
+			to = covered[c].Lower()
+		}
+		if to-from >= minLen {
+			return NewInterval(from, to), true
+		}
+		if c == len(covered) {
+			return nil, false
+		}
+		from = covered[c].Upper()
+	}
+}
+
+// FirstCoveredIn returns the lowest value in i held by one of the covered
+// intervals, or false if i holds none. The covered intervals must be sorted
+// and not overlap, and are searched in logarithmic time.
+func FirstCoveredIn(covered []Interval, i Interval) (int64, bool) {
+	if i.Lower() == i.Upper() {
+		return 0, false
+	}
+	c := sort.Search(len(covered), func(n int) bool {
+		return covered[n].Upper() > i.Lower()
+	})
+	for ; c < len(covered) && covered[c].Lower() < i.Upper(); c++ {
+		if covered[c].Lower() == covered[c].Upper() {
+			continue
+		}
+		if covered[c].Lower() < i.Lower() {
+			return i.Lower(), true
+		}
+		return covered[c].Lower(), true
+	}
+	return 0, false
+}
+
+// LastCoveredIn returns the highest value in i held by one of the covered
+// intervals, or false if i holds none. The covered intervals must be sorted
+// and not overlap, and are searched in logarithmic time.
+func LastCoveredIn(covered []Interval, i Interval) (int64, bool) {
+	if i.Lower() == i.Upper() {
+		return 0, false
+	}
+	c := sort.Search(len(covered), func(n int) bool {
+		return covered[n].Lower() >= i.Upper()
+	}) - 1
+	for ; c >= 0 && covered[c].Upper() > i.Lower(); c-- {
+		if covered[c].Lower() == covered[c].Upper() {
+			continue
+		}
+		if covered[c].Upper() > i.Upper() {
+			return i.Upper() - 1, true
+		}
+		return covered[c].Upper() - 1, true
+	}
+	return 0, false
+}
+
+// FirstGapPointIn returns the lowest value in i that none of the covered
+// intervals hold, or false if they hold all of i. The covered intervals
+// must be sorted and not overlap. The search is logarithmic, plus one step
+// for each covered interval meeting the next.
+func FirstGapPointIn(covered []Interval, i Interval) (int64, bool) {
+	x := i.Lower()
+	c := sort.Search(len(covered), func(n int) bool {
+		return covered[n].Upper() > x
+	})
+	for ; c < len(covered) && covered[c].Lower() <= x; c++ {
+		x = covered[c].Upper()
+	}
+	return x, x < i.Upper()
+}
+
+// LastGapPointIn returns the highest value in i that none of the covered
+// intervals hold, or false if they hold all of i. The covered intervals
+// must be sorted and not overlap. The search is logarithmic, plus one step
+// for each covered interval meeting the next.
+func LastGapPointIn(covered []Interval, i Interval) (int64, bool) {
+	if i.Lower() == i.Upper() {
+		return 0, false
+	}
+	x := i.Upper() - 1
+	c := sort.Search(len(covered), func(n int) bool {
+		return covered[n].Lower() > x
+	}) - 1
+	for ; c >= 0 && covered[c].Upper() > x; c-- {
+		x = covered[c].Lower() - 1
+	}
+	return x, x >= i.Lower()
+}
@@ -0,0 +1,177 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Coverage", func() {
+
+	covered := []Interval{
+		NewInterval(10, 20),
+		NewInterval(15, 25),
+		NewInterval(25, 30),
+		NewInterval(40, 50),
+	}
+
+	Context("partitioning a range", func() {
+
+		It("alternates between covered and uncovered pieces", func() {
+			Ω(Partition(covered, NewInterval(0, 60))).Should(Equal([]Piece{
+				{NewInterval(0, 10), false},
+				{NewInterval(10, 30), true},
+				{NewInterval(30, 40), false},
+				{NewInterval(40, 50), true},
+				{NewInterval(50, 60), false},
+			}))
+		})
+
+		It("clips the pieces to the range", func() {
+			Ω(Partition(covered, NewInterval(12, 45))).Should(Equal([]Piece{
+				{NewInterval(12, 30), true},
+				{NewInterval(30, 40), false},
+				{NewInterval(40, 45), true},
+			}))
+		})
+
+		It("gives one piece for a range inside a single stretch", func() {
+			Ω(Partition(covered, NewInterval(32, 38))).Should(Equal([]Piece{{NewInterval(32, 38), false}}))
+			Ω(Partition(nil, NewInterval(1, 2))).Should(Equal([]Piece{{NewInterval(1, 2), false}}))
+		})
+
+		It("gives no pieces for an empty range", func() {
+			Ω(Partition(covered, NewInterval(15, 15))).Should(BeEmpty())
+		})
+	})
+
+	Context("searching for gaps", func() {
+
+		sorted := []Interval{
+			NewInterval(10, 20),
+			NewInterval(25, 30),
+			NewInterval(30, 35),
+			NewInterval(40, 50),
+		}
+
+		It("finds the first gap long enough", func() {
+			gap, ok := NextGap(sorted, 0, 5)
+			Ω(ok).Should(BeTrue())
+			Ω(gap).Should(Equal(NewInterval(0, 10)))
+
+			gap, ok = NextGap(sorted, 12, 5)
+			Ω(ok).Should(BeTrue())
+			Ω(gap).Should(Equal(NewInterval(20, 25)))
+		})
+
+		It("starts the gap at the point searched from", func() {
+			gap, ok := NextGap(sorted, 22, 0)
+			Ω(ok).Should(BeTrue())
+			Ω(gap).Should(Equal(NewInterval(22, 25)))
+		})
+
+		It("skips gaps that are too short and runs on past the last interval", func() {
+			gap, ok := NextGap(sorted, 12, 6)
+			Ω(ok).Should(BeTrue())
+			Ω(gap).Should(Equal(NewInterval(50, Domain().Upper())))
+		})
+
+		It("finds no gap at the upper edge of the domain", func() {
+			_, ok := NextGap(sorted, Domain().Upper(), 1)
+			Ω(ok).Should(BeFalse())
+			_, ok = NextGap([]Interval{NewInterval(0, Domain().Upper())}, 0, 1)
+			Ω(ok).Should(BeFalse())
+		})
+	})
+
+	Context("searching for covered values", func() {
+
+		sorted := []Interval{
+			NewInterval(10, 20),
+			NewInterval(25, 25),
+			NewInterval(30, 35),
+			NewInterval(35, 40),
+		}
+
+		AssertFirst := func(i Interval, expected int64, ok bool) {
+			x, found := FirstCoveredIn(sorted, i)
+			Ω(found).Should(Equal(ok))
+			if ok {
+				Ω(x).Should(Equal(expected))
+			}
+		}
+
+		AssertLast := func(i Interval, expected int64, ok bool) {
+			x, found := LastCoveredIn(sorted, i)
+			Ω(found).Should(Equal(ok))
+			if ok {
+				Ω(x).Should(Equal(expected))
+			}
+		}
+
+		It("finds the first covered value", func() {
+			AssertFirst(NewInterval(0, 100), 10, true)
+			AssertFirst(NewInterval(12, 100), 12, true)
+			AssertFirst(NewInterval(20, 100), 30, true)
+			AssertFirst(NewInterval(20, 30), 0, false)
+		})
+
+		It("finds the last covered value", func() {
+			AssertLast(NewInterval(0, 100), 39, true)
+			AssertLast(NewInterval(0, 32), 31, true)
+			AssertLast(NewInterval(0, 30), 19, true)
+			AssertLast(NewInterval(20, 30), 0, false)
+		})
+
+		It("finds nothing in an empty range", func() {
+			AssertFirst(NewInterval(15, 15), 0, false)
+			AssertLast(NewInterval(15, 15), 0, false)
+		})
+	})
+
+	Context("searching for uncovered values", func() {
+
+		sorted := []Interval{
+			NewInterval(10, 20),
+			NewInterval(25, 25),
+			NewInterval(30, 35),
+			NewInterval(35, 40),
+		}
+
+		AssertFirst := func(i Interval, expected int64, ok bool) {
+			x, found := FirstGapPointIn(sorted, i)
+			Ω(found).Should(Equal(ok))
+			if ok {
+				Ω(x).Should(Equal(expected))
+			}
+		}
+
+		AssertLast := func(i Interval, expected int64, ok bool) {
+			x, found := LastGapPointIn(sorted, i)
+			Ω(found).Should(Equal(ok))
+			if ok {
+				Ω(x).Should(Equal(expected))
+			}
+		}
+
+		It("finds the first uncovered value", func() {
+			AssertFirst(NewInterval(0, 100), 0, true)
+			AssertFirst(NewInterval(12, 100), 20, true)
+			AssertFirst(NewInterval(32, 100), 40, true)
+			AssertFirst(NewInterval(30, 40), 0, false)
+		})
+
+		It("finds the last uncovered value", func() {
+			AssertLast(NewInterval(0, 100), 99, true)
+			AssertLast(NewInterval(0, 38), 29, true)
+			AssertLast(NewInterval(0, 15), 9, true)
+			AssertLast(NewInterval(12, 18), 0, false)
+		})
+
+		It("finds nothing in an empty range", func() {
+			AssertFirst(NewInterval(22, 22), 0, false)
+			AssertLast(NewInterval(22, 22), 0, false)
+		})
+	})
+})
@@ -0,0 +1,175 @@
+package gallifrey
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// CSVOptions describes how intervals are laid out in CSV records
+type CSVOptions struct {
+	// LowerColumn and UpperColumn name the header fields holding the limits,
+	// "lower" and "upper" if empty
+	LowerColumn, UpperColumn string
+	// InclusiveUpper means the upper column holds the last value covered
+	// rather than the first value not covered
+	InclusiveUpper bool
+	// TimeLayout, if set, means the columns hold times in this layout, which
+	// are converted to slots of Resolution counted from the Unix epoch. As
+	// in Sanitize, they are rounded outward: the lower time back to the
+	// start of its slot, and the upper time on to the end of its slot.
+	TimeLayout string
+	// Resolution is the size of a slot for time columns, a second if zero
+	Resolution time.Duration
+}
+
+func (o CSVOptions) columns() (string, string) {
+	lower, upper := o.LowerColumn, o.UpperColumn
+	if lower == "" {
+		lower = "lower"
+	}
+	if upper == "" {
+		upper = "upper"
+	}
+	return lower, upper
+}
+
+func (o CSVOptions) resolution() time.Duration {
+	if o.Resolution == 0 {
+		return time.Second
+	}
+	return o.Resolution
+}
+
+// ReadCSV reads intervals from CSV with a header record naming its columns.
+// Empty input gives no intervals. Records whose limits cannot be parsed fail
+// with ErrInvalidInterval, and limits outside Domain with ErrOverflow.
+func ReadCSV(r io.Reader, opts CSVOptions) ([]Interval, error) {
+	records := csv.NewReader(r)
+	header, err := records.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lowerName, upperName := opts.columns()
+	lowerField, upperField := -1, -1
+	for f, name := range header {
+		switch name {
+		case lowerName:
+			lowerField = f
+		case upperName:
+			upperField = f
+		}
+	}
+	if lowerField < 0 || upperField < 0 {
+		return nil, fmt.Errorf("%w: header lacks columns %q and %q", ErrInvalidInterval, lowerName, upperName)
+	}
+	var intervals []Interval
+	for row := 2; ; row++ {
+		record, err := records.Read()
+		if err == io.EOF {
+			return intervals, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		lowerFloor, lowerCeil, err := opts.parse(record[lowerField])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		upperFloor, upperCeil, err := opts.parse(record[upperField])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if upperFloor < lowerFloor || (upperFloor == lowerFloor && upperCeil < lowerCeil) {
+			lowerFloor, lowerCeil, upperFloor, upperCeil = upperFloor, upperCeil, lowerFloor, lowerCeil
+		}
+		u := upperCeil
+		if opts.InclusiveUpper {
+			// The slot holding the last value covered is covered in full
+			if upperFloor == math.MaxInt64 {
+				return nil, fmt.Errorf("%w: row %d: inclusive upper limit %d", ErrOverflow, row, upperFloor)
+			}
+			u = upperFloor + 1
+		}
+		i, err := NewCheckedInterval(lowerFloor, u)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		intervals = append(intervals, i)
+	}
+}
+
+// parse reads one limit, returning the slots found by rounding it down and
+// up; these are the same unless it is a time between slots
+func (o CSVOptions) parse(field string) (int64, int64, error) {
+	if o.TimeLayout == "" {
+		x, err := strconv.ParseInt(field, 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, 0, fmt.Errorf("%w: %s", ErrOverflow, field)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %q is not an integer", ErrInvalidInterval, field)
+		}
+		return x, x, nil
+	}
+	t, err := time.Parse(o.TimeLayout, field)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalidInterval, err)
+	}
+	slot, err := NewQuantizedTimeInterval(t, t, o.resolution(), RoundOutward)
+	if err != nil {
+		return 0, 0, err
+	}
+	return slot.Lower(), slot.Upper(), nil
+}
+
+// WriteCSV writes intervals as CSV, preceded by a header record. Intervals
+// of zero span cannot be written with an inclusive upper limit and fail
+// with ErrInvalidInterval.
+func WriteCSV(w io.Writer, intervals []Interval, opts CSVOptions) error {
+	records := csv.NewWriter(w)
+	lowerName, upperName := opts.columns()
+	if err := records.Write([]string{lowerName, upperName}); err != nil {
+		return err
+	}
+	for _, i := range intervals {
+		l, u := i.Lower(), i.Upper()
+		if opts.InclusiveUpper {
+			if l == u {
+				return fmt.Errorf("%w: [%d, %d) has no last value", ErrInvalidInterval, l, u)
+			}
+			u--
+		}
+		lower, err := opts.format(l)
+		if err != nil {
+			return err
+		}
+		upper, err := opts.format(u)
+		if err != nil {
+			return err
+		}
+		if err := records.Write([]string{lower, upper}); err != nil {
+			return err
+		}
+	}
+	records.Flush()
+	return records.Error()
+}
+
+func (o CSVOptions) format(x int64) (string, error) {
+	if o.TimeLayout == "" {
+		return strconv.FormatInt(x, 10), nil
+	}
+	t, err := slotTime(x, o.resolution())
+	if err != nil {
+		return "", err
+	}
+	return t.Format(o.TimeLayout), nil
+}
@@ -0,0 +1,120 @@
+package gallifrey_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CSV", func() {
+
+	var opts CSVOptions
+
+	BeforeEach(func() {
+		opts = CSVOptions{}
+	})
+
+	read := func(input string) []Interval {
+		intervals, err := ReadCSV(strings.NewReader(input), opts)
+		Ω(err).ShouldNot(HaveOccurred())
+		return intervals
+	}
+
+	write := func(intervals ...Interval) string {
+		var buf bytes.Buffer
+		Ω(WriteCSV(&buf, intervals, opts)).Should(Succeed())
+		return buf.String()
+	}
+
+	It("reads exclusive upper limits by default", func() {
+		Ω(read("lower,upper\n0,5\n10,7\n")).Should(Equal([]Interval{NewInterval(0, 5), NewInterval(7, 10)}))
+	})
+
+	It("reads named columns in any position", func() {
+		opts.LowerColumn, opts.UpperColumn = "start", "end"
+		Ω(read("id,end,start\na,5,0\n")).Should(Equal([]Interval{NewInterval(0, 5)}))
+	})
+
+	It("reads inclusive upper limits", func() {
+		opts.InclusiveUpper = true
+		Ω(read("lower,upper\n0,4\n6,6\n")).Should(Equal([]Interval{NewInterval(0, 5), NewInterval(6, 7)}))
+	})
+
+	It("reads times in the given layout", func() {
+		opts.TimeLayout = time.RFC3339
+		opts.Resolution = time.Hour
+		Ω(read("lower,upper\n1970-01-01T02:00:00Z,1970-01-01T05:30:00Z\n")).
+			Should(Equal([]Interval{NewInterval(2, 6)}))
+	})
+
+	It("rounds times outward whichever order they come in", func() {
+		opts.TimeLayout = time.RFC3339
+		opts.Resolution = time.Hour
+		Ω(read("lower,upper\n1970-01-01T05:30:00Z,1970-01-01T02:15:00Z\n")).
+			Should(Equal([]Interval{NewInterval(2, 6)}))
+	})
+
+	It("covers the slot holding an inclusive upper time", func() {
+		opts.TimeLayout = time.RFC3339
+		opts.Resolution = time.Hour
+		opts.InclusiveUpper = true
+		Ω(read("lower,upper\n1970-01-01T02:00:00Z,1970-01-01T05:00:00Z\n")).
+			Should(Equal([]Interval{NewInterval(2, 6)}))
+	})
+
+	It("reads empty input as no intervals", func() {
+		Ω(read("")).Should(BeEmpty())
+	})
+
+	It("rejects limits outside the domain", func() {
+		_, err := ReadCSV(strings.NewReader("lower,upper\n-9223372036854775808,9223372036854775806\n"), opts)
+		Ω(err).Should(MatchError(ErrOverflow))
+		_, err = ReadCSV(strings.NewReader("lower,upper\n0,99999999999999999999\n"), opts)
+		Ω(err).Should(MatchError(ErrOverflow))
+	})
+
+	It("keeps the overflow error for times out of range", func() {
+		opts.TimeLayout = time.RFC3339
+		_, err := ReadCSV(strings.NewReader("lower,upper\n1970-01-01T00:00:00Z,3000-01-01T00:00:00Z\n"), opts)
+		Ω(err).Should(MatchError(ErrOverflow))
+	})
+
+	It("rejects missing columns", func() {
+		_, err := ReadCSV(strings.NewReader("from,to\n0,5\n"), opts)
+		Ω(err).Should(MatchError(ErrInvalidInterval))
+	})
+
+	It("rejects unparseable limits", func() {
+		_, err := ReadCSV(strings.NewReader("lower,upper\n0,five\n"), opts)
+		Ω(err).Should(MatchError(ErrInvalidInterval))
+		Ω(err.Error()).Should(ContainSubstring("row 2"))
+	})
+
+	It("writes exclusive upper limits by default", func() {
+		Ω(write(NewInterval(0, 5))).Should(Equal("lower,upper\n0,5\n"))
+	})
+
+	It("writes inclusive upper limits", func() {
+		opts.InclusiveUpper = true
+		opts.LowerColumn, opts.UpperColumn = "first", "last"
+		Ω(write(NewInterval(0, 5))).Should(Equal("first,last\n0,4\n"))
+	})
+
+	It("refuses to write an empty interval inclusively", func() {
+		opts.InclusiveUpper = true
+		var buf bytes.Buffer
+		Ω(WriteCSV(&buf, []Interval{NewInterval(3, 3)}, opts)).Should(MatchError(ErrInvalidInterval))
+	})
+
+	It("round trips times", func() {
+		opts.TimeLayout = time.RFC3339
+		opts.InclusiveUpper = true
+		intervals := []Interval{NewInterval(1000, 2000), NewInterval(-50, 10)}
+		Ω(read(write(intervals...))).Should(Equal(intervals))
+	})
+})
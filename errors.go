@@ -0,0 +1,13 @@
+package gallifrey
+
+import "errors"
+
+var (
+	// ErrInvalidInterval is returned when input cannot describe an interval
+	ErrInvalidInterval = errors.New("gallifrey: invalid interval")
+	// ErrOverflow is returned when a limit or span does not fit in an int64
+	ErrOverflow = errors.New("gallifrey: interval overflows int64")
+	// ErrNotCovered is returned when the intervals given run out before a
+	// query about their coverage can be answered
+	ErrNotCovered = errors.New("gallifrey: not enough coverage")
+)
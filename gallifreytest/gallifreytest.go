@@ -0,0 +1,110 @@
+// Package gallifreytest provides generators and assertions for testing code
+// built on gallifrey intervals.
+package gallifreytest
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/ghostlang/gallifrey"
+)
+
+// Disjoint returns n sorted intervals laid out from lower, none of which
+// overlap or meet. Each has a span between 1 and maxSpan and is followed by
+// a gap between 1 and maxGap, so the ratio of the two controls how densely
+// the result covers its range and n controls how fragmented it is. It
+// panics if maxSpan or maxGap is less than 1.
+func Disjoint(r *rand.Rand, n int, lower, maxSpan, maxGap int64) []gallifrey.Interval {
+	if maxSpan < 1 || maxGap < 1 {
+		panic(fmt.Sprintf("gallifreytest: maxSpan %d and maxGap %d must be at least 1", maxSpan, maxGap))
+	}
+	result := make([]gallifrey.Interval, n)
+	for i := range result {
+		span := r.Int63n(maxSpan) + 1
+		result[i] = gallifrey.NewIntervalOfSpan(lower, span)
+		lower += span + r.Int63n(maxGap) + 1
+	}
+	return result
+}
+
+// Overlapping returns n unsorted intervals lying within bounds, which may
+// overlap one another freely. Each has a span between 1 and maxSpan, or the
+// span of bounds if that is smaller. It panics if maxSpan or the span of
+// bounds is less than 1.
+func Overlapping(r *rand.Rand, n int, bounds gallifrey.Interval, maxSpan int64) []gallifrey.Interval {
+	if maxSpan < 1 || bounds.Span() < 1 {
+		panic(fmt.Sprintf("gallifreytest: maxSpan %d and bounds span %d must be at least 1", maxSpan, bounds.Span()))
+	}
+	result := make([]gallifrey.Interval, n)
+	for i := range result {
+		span := r.Int63n(maxSpan) + 1
+		if span > bounds.Span() {
+			span = bounds.Span()
+		}
+		lower := bounds.Lower() + r.Int63n(bounds.Span()-span+1)
+		result[i] = gallifrey.NewIntervalOfSpan(lower, span)
+	}
+	return result
+}
+
+// DensitySpec describes the shape of a synthetic set of intervals
+type DensitySpec struct {
+	// Count is the number of intervals, and so how fragmented the set is
+	Count int
+	// Lower is where the first interval starts
+	Lower int64
+	// MeanSpan is the average span of an interval, at least 1
+	MeanSpan int64
+	// Density is roughly the fraction of the range from Lower to the end of
+	// the last interval that is covered, between 0 and 1 exclusive
+	Density float64
+}
+
+// Generate returns the same disjoint intervals for the same seed and spec,
+// shaped as the spec describes. It panics if the spec is out of range.
+func Generate(seed int64, spec DensitySpec) []gallifrey.Interval {
+	if spec.MeanSpan < 1 || spec.Density <= 0 || spec.Density >= 1 {
+		panic(fmt.Sprintf("gallifreytest: invalid density spec %+v", spec))
+	}
+	meanGap := float64(spec.MeanSpan) * (1 - spec.Density) / spec.Density
+	maxGap := int64(2*meanGap - 1 + 0.5)
+	if maxGap < 1 {
+		maxGap = 1
+	}
+	r := rand.New(rand.NewSource(seed))
+	return Disjoint(r, spec.Count, spec.Lower, 2*spec.MeanSpan-1, maxGap)
+}
+
+// Diff compares got against the golden want, interval by interval, and
+// describes the first difference found. It returns "" if they are equal.
+func Diff(want, got []gallifrey.Interval) string {
+	for i := 0; i < len(want) && i < len(got); i++ {
+		if gallifrey.Relate(want[i], got[i]) != gallifrey.Equals {
+			return fmt.Sprintf("interval %d: want %s, got %s", i, format(want[i]), format(got[i]))
+		}
+	}
+	switch {
+	case len(got) < len(want):
+		return fmt.Sprintf("missing %d intervals from %s", len(want)-len(got), format(want[len(got)]))
+	case len(got) > len(want):
+		return fmt.Sprintf("%d extra intervals from %s", len(got)-len(want), format(got[len(want)]))
+	}
+	return ""
+}
+
+// Check calls generate runs times and tests prop on each input, returning
+// the first input for which prop does not hold. It returns nil if prop held
+// every time.
+func Check(r *rand.Rand, runs int, generate func(*rand.Rand) []gallifrey.Interval, prop func([]gallifrey.Interval) bool) []gallifrey.Interval {
+	for i := 0; i < runs; i++ {
+		input := generate(r)
+		if !prop(input) {
+			return input
+		}
+	}
+	return nil
+}
+
+func format(i gallifrey.Interval) string {
+	return fmt.Sprintf("[%d, %d)", i.Lower(), i.Upper())
+}
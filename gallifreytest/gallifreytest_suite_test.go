@@ -0,0 +1,13 @@
+package gallifreytest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGallifreytest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gallifreytest Suite")
+}
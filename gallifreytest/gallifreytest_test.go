@@ -0,0 +1,94 @@
+package gallifreytest_test
+
+import (
+	"math/rand"
+
+	. "github.com/ghostlang/gallifrey"
+	. "github.com/ghostlang/gallifrey/gallifreytest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Testing helpers", func() {
+
+	var r *rand.Rand
+
+	BeforeEach(func() {
+		r = rand.New(rand.NewSource(GinkgoRandomSeed()))
+	})
+
+	It("generates sorted intervals that neither overlap nor meet", func() {
+		intervals := Disjoint(r, 50, -100, 10, 5)
+		Ω(intervals).Should(HaveLen(50))
+		Ω(intervals[0].Lower()).Should(BeNumerically("==", -100))
+		for i := 1; i < len(intervals); i++ {
+			Ω(Relate(intervals[i-1], intervals[i])).Should(Equal(Before))
+		}
+	})
+
+	It("generates overlapping intervals within bounds", func() {
+		bounds := NewInterval(0, 20)
+		for _, i := range Overlapping(r, 50, bounds, 30) {
+			Ω(i.Lower()).Should(BeNumerically(">=", 0))
+			Ω(i.Upper()).Should(BeNumerically("<=", 20))
+			Ω(i.Span()).Should(BeNumerically(">", 0))
+		}
+	})
+
+	It("refuses generator arguments that cannot give positive spans", func() {
+		Ω(func() { Disjoint(r, 1, 0, 0, 1) }).Should(Panic())
+		Ω(func() { Disjoint(r, 1, 0, 1, 0) }).Should(Panic())
+		Ω(func() { Overlapping(r, 1, NewInterval(0, 10), 0) }).Should(Panic())
+		Ω(func() { Overlapping(r, 1, NewInterval(5, 5), 3) }).Should(Panic())
+	})
+
+	It("generates the same intervals for the same seed", func() {
+		spec := DensitySpec{Count: 100, Lower: 0, MeanSpan: 10, Density: 0.5}
+		Ω(Diff(Generate(42, spec), Generate(42, spec))).Should(BeEmpty())
+		Ω(Diff(Generate(42, spec), Generate(43, spec))).ShouldNot(BeEmpty())
+	})
+
+	It("generates intervals of roughly the density asked for", func() {
+		for _, density := range []float64{0.2, 0.5, 0.8} {
+			intervals := Generate(7, DensitySpec{Count: 2000, Lower: 100, MeanSpan: 20, Density: density})
+			Ω(intervals).Should(HaveLen(2000))
+			Ω(intervals[0].Lower()).Should(BeNumerically("==", 100))
+			var covered int64
+			for _, i := range intervals {
+				covered += i.Span()
+			}
+			extent := intervals[len(intervals)-1].Upper() - intervals[0].Lower()
+			Ω(float64(covered) / float64(extent)).Should(BeNumerically("~", density, 0.05))
+		}
+	})
+
+	It("refuses a density spec out of range", func() {
+		Ω(func() { Generate(1, DensitySpec{Count: 1, MeanSpan: 1, Density: 1}) }).Should(Panic())
+		Ω(func() { Generate(1, DensitySpec{Count: 1, MeanSpan: 0, Density: 0.5}) }).Should(Panic())
+	})
+
+	It("finds no difference between equal lists", func() {
+		Ω(Diff([]Interval{NewInterval(0, 5)}, []Interval{NewInterval(0, 5)})).Should(BeEmpty())
+	})
+
+	It("describes differing intervals", func() {
+		Ω(Diff([]Interval{NewInterval(0, 5)}, []Interval{NewInterval(0, 6)})).
+			Should(Equal("interval 0: want [0, 5), got [0, 6)"))
+	})
+
+	It("describes missing and extra intervals", func() {
+		one := []Interval{NewInterval(0, 5)}
+		two := []Interval{NewInterval(0, 5), NewInterval(7, 9)}
+		Ω(Diff(two, one)).Should(Equal("missing 1 intervals from [7, 9)"))
+		Ω(Diff(one, two)).Should(Equal("1 extra intervals from [7, 9)"))
+	})
+
+	It("returns a counterexample to a property", func() {
+		generate := func(r *rand.Rand) []Interval {
+			return Overlapping(r, 3, NewInterval(0, 10), 5)
+		}
+		Ω(Check(r, 100, generate, func([]Interval) bool { return true })).Should(BeNil())
+		Ω(Check(r, 100, generate, func(is []Interval) bool { return len(is) < 3 })).Should(HaveLen(3))
+	})
+})
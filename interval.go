@@ -1,11 +1,59 @@
 package gallifrey
 
+import (
+	"fmt"
+	"math"
+)
+
+// Interval is a stretch of int64 values that includes its lower limit and
+// excludes its upper one. Functions in this package only ever call Lower
+// and Upper on intervals passed to them, and expect Lower to be no greater
+// than Upper. An implementation carrying extra data, such as a label, is
+// passed through unchanged wherever an operation does not have to build a
+// new interval.
 type Interval interface {
 	Lower() int64
 	Upper() int64
 	Span() int64
 }
 
+// Relation is one of the thirteen basic relations of Allen's interval algebra
+type Relation int
+
+const (
+	Before Relation = iota
+	Meets
+	Overlaps
+	Starts
+	During
+	Finishes
+	Equals
+	FinishedBy
+	Contains
+	StartedBy
+	OverlappedBy
+	MetBy
+	After
+)
+
+var relationNames = [...]string{
+	"before", "meets", "overlaps", "starts", "during", "finishes", "equals",
+	"finished by", "contains", "started by", "overlapped by", "met by", "after",
+}
+
+func (r Relation) String() string {
+	if r < Before || r > After {
+		return "unknown"
+	}
+	return relationNames[r]
+}
+
+// Inverse returns the relation seen from the other interval, so that
+// Relate(a, b).Inverse() == Relate(b, a)
+func (r Relation) Inverse() Relation {
+	return After - r
+}
+
 // NewInterval returns an interval with the given limits
 func NewInterval(l, u int64) Interval {
 	if l > u {
@@ -19,6 +67,43 @@ func NewIntervalOfSpan(l, s int64) Interval {
 	return NewInterval(l, l+s)
 }
 
+const (
+	minLimit int64 = math.MinInt64 / 2
+	maxLimit int64 = math.MaxInt64 / 2
+)
+
+// Domain returns the interval of limits that every operation in this package
+// supports. Any interval within it, negative or of zero span included, has a
+// span that fits in an int64, so Span and the arithmetic built on it cannot
+// overflow. Intervals reaching beyond it can still be built with NewInterval
+// and related to others, but their spans may wrap.
+func Domain() Interval {
+	return interval{minLimit, maxLimit}
+}
+
+// NewCheckedInterval is like NewInterval, but returns ErrOverflow if either
+// limit lies outside Domain
+func NewCheckedInterval(l, u int64) (Interval, error) {
+	for _, limit := range []int64{l, u} {
+		if limit < minLimit || limit > maxLimit {
+			return nil, fmt.Errorf("%w: limit %d is outside the domain", ErrOverflow, limit)
+		}
+	}
+	return NewInterval(l, u), nil
+}
+
+// NewCheckedIntervalOfSpan is like NewIntervalOfSpan, but returns
+// ErrOverflow if either resulting limit would lie outside Domain
+func NewCheckedIntervalOfSpan(l, s int64) (Interval, error) {
+	if l < minLimit || l > maxLimit {
+		return nil, fmt.Errorf("%w: limit %d is outside the domain", ErrOverflow, l)
+	}
+	if s > maxLimit-l || s < minLimit-l {
+		return nil, fmt.Errorf("%w: span %d from %d leaves the domain", ErrOverflow, s, l)
+	}
+	return NewIntervalOfSpan(l, s), nil
+}
+
 type interval struct {
 	l int64
 	u int64
@@ -35,3 +120,45 @@ func (i interval) Upper() int64 {
 func (i interval) Span() int64 {
 	return i.u - i.l
 }
+
+// Relate returns the Allen relation between a and b, such as Meets if a ends
+// exactly where b starts. Testing for one relation is a comparison with its
+// constant: Relate(a, b) == During. Limits shared by a and b take
+// precedence, so an interval of zero span lying on a limit of b starts or
+// finishes it rather than meeting it.
+func Relate(a, b Interval) Relation {
+	al, au := a.Lower(), a.Upper()
+	bl, bu := b.Lower(), b.Upper()
+	switch {
+	case au < bl:
+		return Before
+	case bu < al:
+		return After
+	case al == bl && au == bu:
+		return Equals
+	case al == bl:
+		if au < bu {
+			return Starts
+		}
+		return StartedBy
+	case au == bu:
+		if al > bl {
+			return Finishes
+		}
+		return FinishedBy
+	case au == bl:
+		return Meets
+	case bu == al:
+		return MetBy
+	case al < bl:
+		if au < bu {
+			return Overlaps
+		}
+		return Contains
+	default:
+		if au > bu {
+			return OverlappedBy
+		}
+		return During
+	}
+}
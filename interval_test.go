@@ -1,6 +1,7 @@
 package gallifrey_test
 
 import (
+	"math"
 	"math/rand"
 
 	. "github.com/ghostlang/gallifrey"
@@ -74,4 +75,134 @@ var _ = Describe("Interval", func() {
 			AssertIntervalConsistency()
 		})
 	})
+
+	Context("related to another interval", func() {
+
+		reference := NewInterval(10, 20)
+
+		AssertRelation := func(other Interval, expected Relation) {
+			It("should relate as "+expected.String(), func() {
+				Ω(Relate(other, reference)).Should(Equal(expected))
+			})
+			It("should relate inversely from the other side", func() {
+				Ω(Relate(reference, other)).Should(Equal(expected.Inverse()))
+			})
+		}
+
+		AssertRelation(NewInterval(0, 5), Before)
+		AssertRelation(NewInterval(0, 10), Meets)
+		AssertRelation(NewInterval(5, 15), Overlaps)
+		AssertRelation(NewInterval(10, 15), Starts)
+		AssertRelation(NewInterval(12, 18), During)
+		AssertRelation(NewInterval(15, 20), Finishes)
+		AssertRelation(NewInterval(10, 20), Equals)
+		AssertRelation(NewInterval(5, 20), FinishedBy)
+		AssertRelation(NewInterval(5, 25), Contains)
+		AssertRelation(NewInterval(10, 25), StartedBy)
+		AssertRelation(NewInterval(15, 25), OverlappedBy)
+		AssertRelation(NewInterval(20, 25), MetBy)
+		AssertRelation(NewInterval(25, 30), After)
+
+		It("should start or finish rather than meet at a shared limit", func() {
+			Ω(Relate(NewInterval(10, 10), reference)).Should(Equal(Starts))
+			Ω(Relate(NewInterval(20, 20), reference)).Should(Equal(Finishes))
+		})
+
+		It("should be inverse for every pair of small intervals", func() {
+			for a := int64(0); a < 4; a++ {
+				for b := a; b < 4; b++ {
+					for c := int64(0); c < 4; c++ {
+						for d := c; d < 4; d++ {
+							x, y := NewInterval(a, b), NewInterval(c, d)
+							Ω(Relate(y, x)).Should(Equal(Relate(x, y).Inverse()))
+						}
+					}
+				}
+			}
+		})
+	})
+
+	Context("implemented by the caller", func() {
+
+		It("should relate like any other interval", func() {
+			custom := labelled{5, 15, "maintenance"}
+			Ω(Relate(custom, NewInterval(10, 20))).Should(Equal(Overlaps))
+			Ω(Relate(NewInterval(10, 20), custom)).Should(Equal(OverlappedBy))
+		})
+
+		It("should be accepted wherever an interval is", func() {
+			custom := labelled{5, 15, "maintenance"}
+			count, at := PeakOverlap([]Interval{custom, NewInterval(10, 20)})
+			Ω(count).Should(Equal(2))
+			Ω(at).Should(Equal(NewInterval(10, 15)))
+		})
+	})
+
+	Context("at the edges of the domain", func() {
+
+		domain := Domain()
+
+		It("should have a span that fits in an int64", func() {
+			Ω(domain.Span()).Should(BeNumerically(">", 0))
+			Ω(domain.Span()).Should(BeNumerically("==", domain.Upper()-domain.Lower()))
+			Ω(domain.Lower()).Should(BeNumerically("<", 0))
+		})
+
+		It("should build checked intervals within the domain", func() {
+			interval, err := NewCheckedInterval(domain.Upper(), domain.Lower())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(interval).Should(Equal(domain))
+
+			interval, err = NewCheckedIntervalOfSpan(domain.Upper(), -domain.Span())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(interval).Should(Equal(domain))
+		})
+
+		It("should reject limits outside the domain", func() {
+			_, err := NewCheckedInterval(math.MinInt64, 0)
+			Ω(err).Should(MatchError(ErrOverflow))
+			_, err = NewCheckedInterval(0, math.MaxInt64)
+			Ω(err).Should(MatchError(ErrOverflow))
+		})
+
+		It("should reject spans leaving the domain", func() {
+			_, err := NewCheckedIntervalOfSpan(domain.Upper(), 1)
+			Ω(err).Should(MatchError(ErrOverflow))
+			_, err = NewCheckedIntervalOfSpan(domain.Lower(), -1)
+			Ω(err).Should(MatchError(ErrOverflow))
+			_, err = NewCheckedIntervalOfSpan(math.MaxInt64, -1)
+			Ω(err).Should(MatchError(ErrOverflow))
+		})
+
+		It("should relate intervals at the int64 extremes", func() {
+			low := NewInterval(math.MinInt64, -1)
+			high := NewInterval(0, math.MaxInt64)
+			Ω(Relate(low, high)).Should(Equal(Before))
+			Ω(Relate(NewInterval(math.MinInt64, math.MaxInt64), high)).Should(Equal(FinishedBy))
+		})
+
+		It("should relate negative and single point intervals", func() {
+			Ω(Relate(NewInterval(-10, -5), NewInterval(-5, 0))).Should(Equal(Meets))
+			Ω(Relate(NewInterval(-7, -7), NewInterval(-10, -5))).Should(Equal(During))
+			Ω(Relate(NewInterval(-7, -7), NewInterval(-7, -7))).Should(Equal(Equals))
+		})
+
+		It("should sweep intervals at the int64 extremes", func() {
+			var limits []int64
+			Sweep([][]Interval{{NewInterval(math.MinInt64, math.MaxInt64)}}, func(x int64, active []int) {
+				limits = append(limits, x)
+			})
+			Ω(limits).Should(Equal([]int64{math.MinInt64, math.MaxInt64}))
+		})
+	})
 })
+
+// labelled implements Interval itself rather than embedding one
+type labelled struct {
+	l, u  int64
+	label string
+}
+
+func (i labelled) Lower() int64 { return i.l }
+func (i labelled) Upper() int64 { return i.u }
+func (i labelled) Span() int64  { return i.u - i.l }
@@ -0,0 +1,49 @@
+package gallifrey
+
+// Segment is a stretch of a LayeredCalendar over which one layer is in effect
+type Segment struct {
+	Interval
+	Layer int
+}
+
+// LayeredCalendar stacks layers of intervals, such as a base rota beneath
+// exceptions beneath emergencies, where each layer overrides those before it
+type LayeredCalendar struct {
+	layers [][]Interval
+}
+
+// NewLayeredCalendar returns a calendar of the given layers, in ascending
+// order of priority
+func NewLayeredCalendar(layers ...[]Interval) *LayeredCalendar {
+	return &LayeredCalendar{layers}
+}
+
+// Resolve returns, in order, the segments of i covered by some layer, each
+// with the index of the highest priority layer covering it. Adjacent
+// stretches resolving to the same layer form a single segment, and
+// stretches covered by no layer are left out. Each call sweeps every layer.
+func (c *LayeredCalendar) Resolve(i Interval) []Segment {
+	var segments []Segment
+	from, top := i.Lower(), -1
+	Sweep(c.layers, func(x int64, active []int) {
+		l, u := from, x
+		if l < i.Lower() {
+			l = i.Lower()
+		}
+		if u > i.Upper() {
+			u = i.Upper()
+		}
+		if top >= 0 && l < u {
+			if n := len(segments) - 1; n >= 0 && segments[n].Layer == top && segments[n].Upper() == l {
+				segments[n].Interval = NewInterval(segments[n].Lower(), u)
+			} else {
+				segments = append(segments, Segment{NewInterval(l, u), top})
+			}
+		}
+		from, top = x, -1
+		if len(active) > 0 {
+			top = active[len(active)-1]
+		}
+	})
+	return segments
+}
@@ -0,0 +1,56 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LayeredCalendar", func() {
+
+	var calendar *LayeredCalendar
+
+	BeforeEach(func() {
+		calendar = NewLayeredCalendar(
+			[]Interval{NewInterval(0, 100)},
+			[]Interval{NewInterval(20, 40), NewInterval(60, 70)},
+			[]Interval{NewInterval(30, 65)},
+		)
+	})
+
+	It("resolves each segment to the highest layer covering it", func() {
+		Ω(calendar.Resolve(NewInterval(0, 100))).Should(Equal([]Segment{
+			{NewInterval(0, 20), 0},
+			{NewInterval(20, 30), 1},
+			{NewInterval(30, 65), 2},
+			{NewInterval(65, 70), 1},
+			{NewInterval(70, 100), 0},
+		}))
+	})
+
+	It("clips segments to the resolved interval", func() {
+		Ω(calendar.Resolve(NewInterval(25, 35))).Should(Equal([]Segment{
+			{NewInterval(25, 30), 1},
+			{NewInterval(30, 35), 2},
+		}))
+	})
+
+	It("leaves out stretches covered by no layer", func() {
+		Ω(calendar.Resolve(NewInterval(90, 120))).Should(Equal([]Segment{
+			{NewInterval(90, 100), 0},
+		}))
+		Ω(calendar.Resolve(NewInterval(200, 300))).Should(BeEmpty())
+	})
+
+	It("joins adjacent stretches of the same layer", func() {
+		calendar = NewLayeredCalendar(
+			[]Interval{NewInterval(0, 10), NewInterval(10, 20)},
+			[]Interval{NewInterval(30, 40)},
+		)
+		Ω(calendar.Resolve(NewInterval(0, 40))).Should(Equal([]Segment{
+			{NewInterval(0, 20), 0},
+			{NewInterval(30, 40), 1},
+		}))
+	})
+})
@@ -0,0 +1,197 @@
+package gallifrey
+
+// RelationSet is a disjunction of Allen relations, used to express
+// indefinite knowledge such as "a is before or meets b"
+type RelationSet uint16
+
+// AllRelations is the set of every Allen relation, i.e. no constraint at all
+const AllRelations RelationSet = 1<<(After+1) - 1
+
+var composition [After + 1][After + 1]RelationSet
+
+func init() {
+	// Every arrangement of three intervals can be realised with endpoints
+	// drawn from six distinct values, so enumerating them fills the table
+	var intervals []Interval
+	for l := int64(0); l < 6; l++ {
+		for u := l + 1; u < 6; u++ {
+			intervals = append(intervals, NewInterval(l, u))
+		}
+	}
+	for _, a := range intervals {
+		for _, b := range intervals {
+			for _, c := range intervals {
+				composition[Relate(a, b)][Relate(b, c)] |= NewRelationSet(Relate(a, c))
+			}
+		}
+	}
+}
+
+// NewRelationSet returns the set holding the given relations
+func NewRelationSet(relations ...Relation) RelationSet {
+	var s RelationSet
+	for _, r := range relations {
+		s |= 1 << r
+	}
+	return s
+}
+
+// Has reports whether r is in the set
+func (s RelationSet) Has(r Relation) bool {
+	return s&(1<<r) != 0
+}
+
+// Relations returns the members of the set in order
+func (s RelationSet) Relations() []Relation {
+	var rs []Relation
+	for r := Before; r <= After; r++ {
+		if s.Has(r) {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// Inverse returns the set of inverses of the members of the set
+func (s RelationSet) Inverse() RelationSet {
+	var inv RelationSet
+	for r := Before; r <= After; r++ {
+		if s.Has(r) {
+			inv |= NewRelationSet(r.Inverse())
+		}
+	}
+	return inv
+}
+
+// Compose returns the relations possible between a and c given that a
+// relates to b by a member of s and b relates to c by a member of t
+func (s RelationSet) Compose(t RelationSet) RelationSet {
+	var c RelationSet
+	for r := Before; r <= After; r++ {
+		if !s.Has(r) {
+			continue
+		}
+		for q := Before; q <= After; q++ {
+			if t.Has(q) {
+				c |= composition[r][q]
+			}
+		}
+	}
+	return c
+}
+
+// Network is a set of Allen relation constraints between a fixed number of
+// intervals, identified by index
+type Network struct {
+	constraints [][]RelationSet
+}
+
+// NewNetwork returns an unconstrained network over n intervals
+func NewNetwork(n int) *Network {
+	c := make([][]RelationSet, n)
+	for i := range c {
+		c[i] = make([]RelationSet, n)
+		for j := range c[i] {
+			c[i][j] = AllRelations
+		}
+		c[i][i] = NewRelationSet(Equals)
+	}
+	return &Network{c}
+}
+
+// Size returns the number of intervals in the network
+func (n *Network) Size() int {
+	return len(n.constraints)
+}
+
+// Constrain restricts the relation of interval i to interval j to the
+// members of s, on top of any constraint already present
+func (n *Network) Constrain(i, j int, s RelationSet) {
+	n.constraints[i][j] &= s
+	n.constraints[j][i] &= s.Inverse()
+}
+
+// Relations returns the relations still possible between intervals i and j
+func (n *Network) Relations(i, j int) RelationSet {
+	return n.constraints[i][j]
+}
+
+// PathConsistent prunes relations that cannot hold given every path of
+// length two through the network, and reports false if some pair of
+// intervals is left with no possible relation. A true result does not by
+// itself guarantee that the network is satisfiable; see Satisfiable.
+func (n *Network) PathConsistent() bool {
+	type pair struct{ i, j int }
+	size := n.Size()
+	var queue []pair
+	for i := 0; i < size; i++ {
+		for j := i + 1; j < size; j++ {
+			if n.constraints[i][j] == 0 {
+				return false
+			}
+			queue = append(queue, pair{i, j})
+		}
+	}
+	revise := func(i, k int, s RelationSet) bool {
+		if refined := n.constraints[i][k] & s; refined != n.constraints[i][k] {
+			if refined == 0 {
+				return false
+			}
+			n.Constrain(i, k, refined)
+			queue = append(queue, pair{i, k})
+		}
+		return true
+	}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for k := 0; k < size; k++ {
+			if k == p.i || k == p.j {
+				continue
+			}
+			if !revise(p.i, k, n.constraints[p.i][p.j].Compose(n.constraints[p.j][k])) {
+				return false
+			}
+			if !revise(k, p.j, n.constraints[k][p.i].Compose(n.constraints[p.i][p.j])) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Satisfiable reports whether intervals exist that meet every constraint in
+// the network. It searches over the basic relations of undecided pairs, so
+// it is exponential in the worst case; the network itself is not modified.
+func (n *Network) Satisfiable() bool {
+	c := n.clone()
+	if !c.PathConsistent() {
+		return false
+	}
+	for i := range c.constraints {
+		for j := i + 1; j < len(c.constraints); j++ {
+			rs := c.constraints[i][j].Relations()
+			if len(rs) == 1 {
+				continue
+			}
+			for _, r := range rs {
+				attempt := c.clone()
+				attempt.Constrain(i, j, NewRelationSet(r))
+				if attempt.Satisfiable() {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	// Path consistency decides networks of basic relations
+	return true
+}
+
+func (n *Network) clone() *Network {
+	c := make([][]RelationSet, len(n.constraints))
+	for i := range c {
+		c[i] = append([]RelationSet(nil), n.constraints[i]...)
+	}
+	return &Network{c}
+}
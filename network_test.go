@@ -0,0 +1,97 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Network", func() {
+
+	Context("composing relation sets", func() {
+
+		It("composes before with before", func() {
+			before := NewRelationSet(Before)
+			Ω(before.Compose(before)).Should(Equal(before))
+		})
+
+		It("composes meets with meets", func() {
+			meets := NewRelationSet(Meets)
+			Ω(meets.Compose(meets)).Should(Equal(NewRelationSet(Before)))
+		})
+
+		It("composes overlaps with overlaps", func() {
+			overlaps := NewRelationSet(Overlaps)
+			Ω(overlaps.Compose(overlaps)).Should(Equal(NewRelationSet(Before, Meets, Overlaps)))
+		})
+
+		It("knows nothing when composing during with contains", func() {
+			Ω(NewRelationSet(During).Compose(NewRelationSet(Contains))).Should(Equal(AllRelations))
+		})
+
+		It("treats equals as the identity", func() {
+			equals := NewRelationSet(Equals)
+			for _, r := range AllRelations.Relations() {
+				Ω(equals.Compose(NewRelationSet(r))).Should(Equal(NewRelationSet(r)))
+			}
+		})
+
+		It("inverts each member", func() {
+			Ω(NewRelationSet(Before, Starts).Inverse()).Should(Equal(NewRelationSet(After, StartedBy)))
+		})
+	})
+
+	Context("with a chain of constraints", func() {
+
+		var network *Network
+
+		BeforeEach(func() {
+			network = NewNetwork(3)
+			network.Constrain(0, 1, NewRelationSet(Before, Meets))
+			network.Constrain(1, 2, NewRelationSet(Before))
+		})
+
+		It("infers the relation between the ends of the chain", func() {
+			Ω(network.PathConsistent()).Should(BeTrue())
+			Ω(network.Relations(0, 2)).Should(Equal(NewRelationSet(Before)))
+			Ω(network.Relations(2, 0)).Should(Equal(NewRelationSet(After)))
+		})
+
+		It("is satisfiable", func() {
+			Ω(network.Satisfiable()).Should(BeTrue())
+		})
+
+		It("is not satisfiable when the chain is closed into a cycle", func() {
+			network.Constrain(2, 0, NewRelationSet(Before, During))
+			Ω(network.Satisfiable()).Should(BeFalse())
+			Ω(network.PathConsistent()).Should(BeFalse())
+		})
+
+		It("does not modify the network when checking satisfiability", func() {
+			network.Satisfiable()
+			Ω(network.Relations(0, 2)).Should(Equal(AllRelations))
+		})
+	})
+
+	It("reports contradictory constraints between two intervals", func() {
+		network := NewNetwork(2)
+		network.Constrain(0, 1, NewRelationSet(Before))
+		network.Constrain(0, 1, NewRelationSet(After))
+		Ω(network.Relations(0, 1)).Should(BeZero())
+		Ω(network.PathConsistent()).Should(BeFalse())
+		Ω(network.Satisfiable()).Should(BeFalse())
+	})
+
+	It("finds path consistent networks that are not satisfiable", func() {
+		network := NewNetwork(4)
+		network.Constrain(0, 1, NewRelationSet(Starts, Equals, FinishedBy))
+		network.Constrain(0, 2, NewRelationSet(Overlaps))
+		network.Constrain(0, 3, NewRelationSet(Meets, During, Contains))
+		network.Constrain(1, 2, NewRelationSet(Starts, Contains))
+		network.Constrain(1, 3, NewRelationSet(Finishes, StartedBy))
+		network.Constrain(2, 3, NewRelationSet(Overlaps, OverlappedBy))
+		Ω(network.Satisfiable()).Should(BeFalse())
+		Ω(network.PathConsistent()).Should(BeTrue())
+	})
+})
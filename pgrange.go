@@ -0,0 +1,206 @@
+package gallifrey
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pgTimeLayouts are the timestamptz formats accepted in range literals, the
+// first being the one Postgres itself writes
+var pgTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	time.RFC3339Nano,
+}
+
+// FormatPGRange returns the interval as a Postgres int8range literal in
+// canonical form. Intervals of zero span are written as "empty", and limits
+// at the edges of Domain as unbounded. ParsePGRange reads the literal back
+// to the same interval only if the interval lies within Domain; limits
+// beyond it are written as they are, and parsing them fails with
+// ErrOverflow.
+func FormatPGRange(i Interval) string {
+	s, _ := formatPGRange(i, func(x int64) (string, error) {
+		return strconv.FormatInt(x, 10), nil
+	})
+	return s
+}
+
+// ParsePGRange parses a Postgres int8range literal, with either kind of
+// bound on each side, into the half-open interval holding the same values.
+// Unbounded sides extend to the edges of Domain, and every empty range
+// parses as the zero-span interval at 0. Malformed literals fail with
+// ErrInvalidInterval and values outside Domain with ErrOverflow.
+func ParsePGRange(s string) (Interval, error) {
+	return parsePGRange(s, func(v string, upper, inclusive bool) (int64, int64, error) {
+		x, err := strconv.ParseInt(v, 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, 0, fmt.Errorf("%w: %s", ErrOverflow, v)
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %q is not an integer", ErrInvalidInterval, v)
+		}
+		if x < minLimit || x > maxLimit {
+			return 0, 0, fmt.Errorf("%w: limit %d is outside the domain", ErrOverflow, x)
+		}
+		if upper == inclusive {
+			return x, x + 1, nil
+		}
+		return x, x, nil
+	})
+}
+
+// FormatPGTimeRange returns the interval, in slots of resolution counted
+// from the Unix epoch, as a Postgres tstzrange literal in UTC. Limits at the
+// edges of Domain are written as unbounded, never as infinity.
+func FormatPGTimeRange(i Interval, resolution time.Duration) (string, error) {
+	return formatPGRange(i, func(x int64) (string, error) {
+		t, err := slotTime(x, resolution)
+		if err != nil {
+			return "", err
+		}
+		return `"` + t.Format(pgTimeLayouts[0]) + `"`, nil
+	})
+}
+
+// ParsePGTimeRange parses a Postgres tstzrange literal into an interval of
+// slots of resolution counted from the Unix epoch, covering every slot that
+// holds a time in the range. The lower time is rounded back to the start of
+// its slot whether or not it is included, and the upper time on to the end
+// of its slot, which for an included time on a slot boundary is the end of
+// the slot starting there. Excluding a single instant never drops a slot.
+// Unbounded sides and the special values -infinity and infinity extend to
+// the edges of Domain.
+func ParsePGTimeRange(s string, resolution time.Duration) (Interval, error) {
+	return parsePGRange(s, func(v string, upper, inclusive bool) (int64, int64, error) {
+		switch strings.ToLower(v) {
+		case "-infinity":
+			return math.MinInt64, minLimit, nil
+		case "infinity":
+			return math.MaxInt64, maxLimit, nil
+		}
+		for _, layout := range pgTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				slots, err := NewQuantizedTimeInterval(t, t, resolution, RoundOutward)
+				if err != nil {
+					return 0, 0, err
+				}
+				switch {
+				case !upper:
+					return t.UnixNano(), slots.Lower(), nil
+				case inclusive:
+					return t.UnixNano(), slots.Lower() + 1, nil
+				}
+				return t.UnixNano(), slots.Upper(), nil
+			}
+		}
+		return 0, 0, fmt.Errorf("%w: %q is not a timestamp", ErrInvalidInterval, v)
+	})
+}
+
+func formatPGRange(i Interval, format func(int64) (string, error)) (string, error) {
+	l, u := i.Lower(), i.Upper()
+	if l == u {
+		return "empty", nil
+	}
+	lower, upper := "(", ")"
+	if l != minLimit {
+		v, err := format(l)
+		if err != nil {
+			return "", err
+		}
+		lower = "[" + v
+	}
+	if u != maxLimit {
+		v, err := format(u)
+		if err != nil {
+			return "", err
+		}
+		upper = v + ")"
+	}
+	return lower + "," + upper, nil
+}
+
+// parsePGRange parses a range literal, using bound to read each bound value.
+// Given whether the value is the upper bound and whether it is included,
+// bound returns a key ordering the values as written and the limit of the
+// half-open interval on that side.
+func parsePGRange(s string, bound func(v string, upper, inclusive bool) (int64, int64, error)) (Interval, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		return NewInterval(0, 0), nil
+	}
+	if len(s) < 3 || (s[0] != '[' && s[0] != '(') || (s[len(s)-1] != ']' && s[len(s)-1] != ')') {
+		return nil, fmt.Errorf("%w: %q is not a range literal", ErrInvalidInterval, s)
+	}
+	values, bounded, err := splitPGRange(s[1 : len(s)-1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrInvalidInterval, s, err)
+	}
+	inclusive := [2]bool{s[0] == '[', s[len(s)-1] == ']'}
+	keys := [2]int64{math.MinInt64, math.MaxInt64}
+	limits := [2]int64{minLimit, maxLimit}
+	for n := range limits {
+		if !bounded[n] {
+			continue
+		}
+		if keys[n], limits[n], err = bound(values[n], n == 1, inclusive[n]); err != nil {
+			return nil, err
+		}
+	}
+	if keys[0] > keys[1] {
+		return nil, fmt.Errorf("%w: %q has its lower bound above its upper bound", ErrInvalidInterval, s)
+	}
+	l, u := limits[0], limits[1]
+	if l >= u || (keys[0] == keys[1] && !(inclusive[0] && inclusive[1])) {
+		return NewInterval(0, 0), nil
+	}
+	return NewCheckedInterval(l, u)
+}
+
+// splitPGRange splits the inside of a range literal into its two bound
+// values, undoing quoting, and reports which of them are present
+func splitPGRange(inner string) (values [2]string, bounded [2]bool, err error) {
+	rest := inner
+	for n := range values {
+		if n == 1 {
+			if rest == "" || rest[0] != ',' {
+				return values, bounded, errors.New("expected two bounds")
+			}
+			rest = rest[1:]
+		}
+		var b strings.Builder
+		quoted := false
+		for len(rest) > 0 && (quoted || rest[0] != ',') {
+			c := rest[0]
+			rest = rest[1:]
+			switch {
+			case c == '\\' && len(rest) > 0:
+				b.WriteByte(rest[0])
+				rest = rest[1:]
+			case c == '"' && quoted && len(rest) > 0 && rest[0] == '"':
+				b.WriteByte('"')
+				rest = rest[1:]
+			case c == '"':
+				quoted = !quoted
+				bounded[n] = true
+			default:
+				b.WriteByte(c)
+			}
+		}
+		if quoted {
+			return values, bounded, errors.New("unterminated quote")
+		}
+		values[n] = strings.TrimSpace(b.String())
+		bounded[n] = bounded[n] || values[n] != ""
+	}
+	if rest != "" {
+		return values, bounded, errors.New("expected two bounds")
+	}
+	return values, bounded, nil
+}
@@ -0,0 +1,115 @@
+package gallifrey_test
+
+import (
+	"time"
+
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Postgres ranges", func() {
+
+	AssertParsed := func(literal string, expected Interval) {
+		It("parses "+literal, func() {
+			interval, err := ParsePGRange(literal)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(interval).Should(Equal(expected))
+		})
+	}
+
+	AssertRejected := func(literal string, expected error) {
+		It("rejects "+literal, func() {
+			_, err := ParsePGRange(literal)
+			Ω(err).Should(MatchError(expected))
+		})
+	}
+
+	AssertParsed("[3,7)", NewInterval(3, 7))
+	AssertParsed("[3,7]", NewInterval(3, 8))
+	AssertParsed("(3,7)", NewInterval(4, 7))
+	AssertParsed("(3,7]", NewInterval(4, 8))
+	AssertParsed(" [-7, -3) ", NewInterval(-7, -3))
+	AssertParsed(`["3","7")`, NewInterval(3, 7))
+	AssertParsed("[,7)", NewInterval(Domain().Lower(), 7))
+	AssertParsed("(3,)", NewInterval(4, Domain().Upper()))
+	AssertParsed("(,)", Domain())
+	AssertParsed("empty", NewInterval(0, 0))
+	AssertParsed("EMPTY", NewInterval(0, 0))
+	AssertParsed("[3,3)", NewInterval(0, 0))
+	AssertParsed("(3,4)", NewInterval(0, 0))
+	AssertParsed("[3,3]", NewInterval(3, 4))
+
+	AssertRejected("3,7", ErrInvalidInterval)
+	AssertRejected("[3)", ErrInvalidInterval)
+	AssertRejected("[3,7,9)", ErrInvalidInterval)
+	AssertRejected("[x,7)", ErrInvalidInterval)
+	AssertRejected(`["3,7)`, ErrInvalidInterval)
+	AssertRejected("[7,3)", ErrInvalidInterval)
+	AssertRejected("[0,9223372036854775807)", ErrOverflow)
+	AssertRejected("[0,99999999999999999999)", ErrOverflow)
+
+	It("formats canonical literals", func() {
+		Ω(FormatPGRange(NewInterval(3, 7))).Should(Equal("[3,7)"))
+		Ω(FormatPGRange(NewInterval(-7, -3))).Should(Equal("[-7,-3)"))
+		Ω(FormatPGRange(NewInterval(5, 5))).Should(Equal("empty"))
+		Ω(FormatPGRange(NewInterval(Domain().Lower(), 7))).Should(Equal("(,7)"))
+		Ω(FormatPGRange(Domain())).Should(Equal("(,)"))
+	})
+
+	Context("over timestamps", func() {
+
+		It("formats a tstzrange literal", func() {
+			literal, err := FormatPGTimeRange(NewInterval(0, 36), time.Hour)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(literal).Should(Equal(`["1970-01-01 00:00:00+00","1970-01-02 12:00:00+00")`))
+		})
+
+		It("parses what it formats", func() {
+			literal, _ := FormatPGTimeRange(NewInterval(-5, 36), time.Hour)
+			interval, err := ParsePGTimeRange(literal, time.Hour)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(interval).Should(Equal(NewInterval(-5, 36)))
+		})
+
+		It("parses offsets and inclusive bounds", func() {
+			interval, err := ParsePGTimeRange(`["1970-01-01 05:30:00+05:30","1970-01-01 03:00:00+00"]`, time.Hour)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(interval).Should(Equal(NewInterval(0, 4)))
+		})
+
+		AssertTimeParsed := func(literal string, resolution time.Duration, expected Interval) {
+			interval, err := ParsePGTimeRange(literal, resolution)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(interval).Should(Equal(expected))
+		}
+
+		It("keeps the slot of an excluded lower bound on a slot boundary", func() {
+			AssertTimeParsed(`("1970-01-01 01:00:00+00","1970-01-01 03:00:00+00")`, time.Hour, NewInterval(1, 3))
+		})
+
+		It("keeps the slot of an excluded lower bound between slots", func() {
+			AssertTimeParsed(`("1970-01-01 01:30:00+00","1970-01-01 03:00:00+00")`, time.Hour, NewInterval(1, 3))
+		})
+
+		It("covers the slot of a range shorter than one slot", func() {
+			AssertTimeParsed(`["1970-01-01 01:00:00.5+00","1970-01-01 01:00:00.7+00")`, time.Second, NewInterval(3600, 3601))
+		})
+
+		It("parses ranges of one instant as Postgres does", func() {
+			AssertTimeParsed(`["1970-01-01 01:30:00+00","1970-01-01 01:30:00+00"]`, time.Hour, NewInterval(1, 2))
+			AssertTimeParsed(`["1970-01-01 01:30:00+00","1970-01-01 01:30:00+00")`, time.Hour, NewInterval(0, 0))
+		})
+
+		It("parses infinite bounds as the edges of the domain", func() {
+			AssertTimeParsed(`[-infinity,"1970-01-01 03:00:00+00")`, time.Hour, NewInterval(Domain().Lower(), 3))
+			AssertTimeParsed(`["1970-01-01 03:00:00+00",infinity]`, time.Hour, NewInterval(3, Domain().Upper()))
+		})
+
+		It("rejects values that are not timestamps", func() {
+			_, err := ParsePGTimeRange(`["yesterday",)`, time.Hour)
+			Ω(err).Should(MatchError(ErrInvalidInterval))
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package gallifrey
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const portLimit = 1 << 16
+
+// PortSet is a set of TCP or UDP port numbers
+type PortSet struct {
+	intervals []Interval
+}
+
+// ParsePortSet parses a comma separated list of ports and inclusive port
+// ranges, such as "80,443,8000-8999". Items may overlap and come in any
+// order; an empty list gives an empty set. Ports are plain decimal numbers,
+// without signs or leading zeros. Malformed items and ports above 65535 fail
+// with ErrInvalidInterval.
+func ParsePortSet(s string) (*PortSet, error) {
+	var intervals []Interval
+	if strings.TrimSpace(s) != "" {
+		for _, item := range strings.Split(s, ",") {
+			first, last, isRange := strings.Cut(strings.TrimSpace(item), "-")
+			if !isRange {
+				last = first
+			}
+			l, err := parsePort(first)
+			if err != nil {
+				return nil, err
+			}
+			u, err := parsePort(last)
+			if err != nil {
+				return nil, err
+			}
+			if u < l {
+				return nil, fmt.Errorf("%w: port range %q is reversed", ErrInvalidInterval, item)
+			}
+			intervals = append(intervals, NewInterval(l, u+1))
+		}
+	}
+	return newPortSet([][]Interval{intervals}, func(active []int) bool {
+		return len(active) > 0
+	}), nil
+}
+
+func parsePort(s string) (int64, error) {
+	digits := strings.TrimSpace(s)
+	if digits == "" || strings.Trim(digits, "0123456789") != "" || (digits[0] == '0' && digits != "0") {
+		return 0, fmt.Errorf("%w: %q is not a port", ErrInvalidInterval, s)
+	}
+	port, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || port >= portLimit {
+		return 0, fmt.Errorf("%w: %q is not a port", ErrInvalidInterval, s)
+	}
+	return port, nil
+}
+
+func newPortSet(collections [][]Interval, keep func(active []int) bool) *PortSet {
+	return &PortSet{combine(collections, keep)}
+}
+
+// Contains reports whether the set holds the given port
+func (s *PortSet) Contains(port int) bool {
+	for _, i := range s.intervals {
+		if int64(port) >= i.Lower() && int64(port) < i.Upper() {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns the ports in either set
+func (s *PortSet) Union(other *PortSet) *PortSet {
+	return newPortSet([][]Interval{s.intervals, other.intervals}, func(active []int) bool {
+		return len(active) > 0
+	})
+}
+
+// Intersect returns the ports in both sets
+func (s *PortSet) Intersect(other *PortSet) *PortSet {
+	return newPortSet([][]Interval{s.intervals, other.intervals}, func(active []int) bool {
+		return len(active) == 2
+	})
+}
+
+// Subtract returns the ports in the set but not in other
+func (s *PortSet) Subtract(other *PortSet) *PortSet {
+	return newPortSet([][]Interval{s.intervals, other.intervals}, func(active []int) bool {
+		return len(active) == 1 && active[0] == 0
+	})
+}
+
+// Ranges returns the fewest intervals, in ascending order, that cover
+// exactly the ports in the set. Each includes its lower port and excludes
+// its upper one.
+func (s *PortSet) Ranges() []Interval {
+	return append([]Interval(nil), s.intervals...)
+}
+
+// MinimalCover returns at most maxRanges intervals, in ascending order,
+// covering every port in the set, as the function MinimalCover does for
+// any intervals.
+func (s *PortSet) MinimalCover(maxRanges int) []Interval {
+	cover, _ := minimalCover(s.intervals, maxRanges)
+	return cover
+}
+
+// LargestNRanges returns at most n of the set's ranges and the ports they
+// leave out, as the function LargestNRanges does for any intervals
+func (s *PortSet) LargestNRanges(n int) ([]Interval, *PortSet) {
+	ranges, remainder := largestNRanges(s.intervals, n)
+	return ranges, &PortSet{remainder}
+}
+
+// String returns the set in the syntax read by ParsePortSet, using as few
+// items as possible
+func (s *PortSet) String() string {
+	items := make([]string, len(s.intervals))
+	for n, i := range s.intervals {
+		items[n] = strconv.FormatInt(i.Lower(), 10)
+		if i.Span() > 1 {
+			items[n] += "-" + strconv.FormatInt(i.Upper()-1, 10)
+		}
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,98 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PortSet", func() {
+
+	parse := func(s string) *PortSet {
+		set, err := ParsePortSet(s)
+		Ω(err).ShouldNot(HaveOccurred())
+		return set
+	}
+
+	It("parses ports and ranges", func() {
+		set := parse("80, 443,8000-8999")
+		Ω(set.Contains(80)).Should(BeTrue())
+		Ω(set.Contains(81)).Should(BeFalse())
+		Ω(set.Contains(8000)).Should(BeTrue())
+		Ω(set.Contains(8999)).Should(BeTrue())
+		Ω(set.Contains(9000)).Should(BeFalse())
+	})
+
+	It("merges overlapping and adjacent items minimally", func() {
+		set := parse("8080,22,8000-8100,23,21,65535")
+		Ω(set.String()).Should(Equal("21-23,8000-8100,65535"))
+		Ω(set.Ranges()).Should(Equal([]Interval{
+			NewInterval(21, 24),
+			NewInterval(8000, 8101),
+			NewInterval(65535, 65536),
+		}))
+	})
+
+	It("parses an empty list as an empty set", func() {
+		Ω(parse("").Ranges()).Should(BeEmpty())
+		Ω(parse(" ").String()).Should(Equal(""))
+	})
+
+	It("takes unions", func() {
+		Ω(parse("80,443").Union(parse("81-442")).String()).Should(Equal("80-443"))
+	})
+
+	It("takes intersections", func() {
+		Ω(parse("1-100,200-300").Intersect(parse("50-250")).String()).Should(Equal("50-100,200-250"))
+	})
+
+	It("subtracts", func() {
+		Ω(parse("1-1024").Subtract(parse("22,80,443")).String()).
+			Should(Equal("1-21,23-79,81-442,444-1024"))
+	})
+
+	It("keeps an exact cover within the budget", func() {
+		Ω(parse("22,80,443").MinimalCover(3)).Should(Equal(parse("22,80,443").Ranges()))
+	})
+
+	It("closes the smallest gaps to meet the budget", func() {
+		set := parse("22,80,443,8000-8080,8443")
+		Ω(set.MinimalCover(3)).Should(Equal([]Interval{
+			NewInterval(22, 444),
+			NewInterval(8000, 8081),
+			NewInterval(8443, 8444),
+		}))
+		Ω(set.MinimalCover(1)).Should(Equal([]Interval{NewInterval(22, 8444)}))
+		Ω(set.MinimalCover(0)).Should(Equal(set.MinimalCover(1)))
+	})
+
+	It("covers nothing for an empty set", func() {
+		Ω(parse("").MinimalCover(2)).Should(BeEmpty())
+	})
+
+	It("keeps the largest ranges and reports the remainder", func() {
+		ranges, remainder := parse("22,80-89,443,8000-8080,8443").LargestNRanges(2)
+		Ω(ranges).Should(Equal([]Interval{NewInterval(80, 90), NewInterval(8000, 8081)}))
+		Ω(remainder.String()).Should(Equal("22,443,8443"))
+	})
+
+	It("keeps every range within the budget", func() {
+		ranges, remainder := parse("22,80").LargestNRanges(5)
+		Ω(ranges).Should(Equal(parse("22,80").Ranges()))
+		Ω(remainder.Ranges()).Should(BeEmpty())
+	})
+
+	It("keeps nothing with no budget", func() {
+		ranges, remainder := parse("22,80").LargestNRanges(0)
+		Ω(ranges).Should(BeEmpty())
+		Ω(remainder.String()).Should(Equal("22,80"))
+	})
+
+	It("rejects malformed items", func() {
+		for _, s := range []string{"http", "80,", "90-80", "65536", "-1", "1-2-3", "+80", "80,443,1-3,+5", "1-+3", "080", "8 0"} {
+			_, err := ParsePortSet(s)
+			Ω(err).Should(MatchError(ErrInvalidInterval))
+		}
+	})
+})
@@ -0,0 +1,97 @@
+package gallifrey
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RoundPolicy decides which slot a timestamp falls in when it does not lie
+// exactly on a slot boundary
+type RoundPolicy int
+
+const (
+	// RoundFloor moves both limits back to the boundary at or before them
+	RoundFloor RoundPolicy = iota
+	// RoundCeil moves both limits on to the boundary at or after them
+	RoundCeil
+	// RoundNearest moves both limits to the closest boundary, rounding
+	// halfway points up
+	RoundNearest
+	// RoundOutward floors the lower limit and ceils the upper, so the
+	// result covers every slot the times touch
+	RoundOutward
+	// RoundInward ceils the lower limit and floors the upper, so the result
+	// covers only slots lying wholly between the times
+	RoundInward
+)
+
+var (
+	minQuantizable = time.Unix(0, math.MinInt64)
+	maxQuantizable = time.Unix(0, math.MaxInt64)
+)
+
+// NewQuantizedTimeInterval returns the interval between start and end in
+// slots of the given resolution counted from the Unix epoch, rounding each
+// limit according to policy. Times are swapped if end is before start, and
+// under RoundInward a stretch holding no whole slot gives an interval of
+// zero span. Only times representable as nanoseconds since the epoch, from
+// 1678 to 2262, can be quantized.
+func NewQuantizedTimeInterval(start, end time.Time, resolution time.Duration, policy RoundPolicy) (Interval, error) {
+	if resolution <= 0 {
+		return nil, fmt.Errorf("%w: resolution %v is not positive", ErrInvalidInterval, resolution)
+	}
+	if end.Before(start) {
+		start, end = end, start
+	}
+	if start.Before(minQuantizable) || end.After(maxQuantizable) {
+		return nil, fmt.Errorf("%w: %v to %v is outside the quantizable range", ErrOverflow, start, end)
+	}
+	lowerPolicy, upperPolicy := policy, policy
+	switch policy {
+	case RoundFloor, RoundCeil, RoundNearest:
+	case RoundOutward:
+		lowerPolicy, upperPolicy = RoundFloor, RoundCeil
+	case RoundInward:
+		lowerPolicy, upperPolicy = RoundCeil, RoundFloor
+	default:
+		return nil, fmt.Errorf("%w: unknown rounding policy %d", ErrInvalidInterval, policy)
+	}
+	l := quantize(start.UnixNano(), int64(resolution), lowerPolicy)
+	u := quantize(end.UnixNano(), int64(resolution), upperPolicy)
+	if u < l {
+		u = l
+	}
+	return NewInterval(l, u), nil
+}
+
+func quantize(n, resolution int64, policy RoundPolicy) int64 {
+	q, r := n/resolution, n%resolution
+	if r < 0 {
+		q, r = q-1, r+resolution
+	}
+	if r == 0 {
+		return q
+	}
+	switch policy {
+	case RoundCeil:
+		q++
+	case RoundNearest:
+		if r >= resolution-r {
+			q++
+		}
+	}
+	return q
+}
+
+// slotTime returns the time at which the given slot of resolution starts
+func slotTime(slot int64, resolution time.Duration) (time.Time, error) {
+	r := int64(resolution)
+	if r <= 0 {
+		return time.Time{}, fmt.Errorf("%w: resolution %v is not positive", ErrInvalidInterval, resolution)
+	}
+	if slot > math.MaxInt64/r || slot < math.MinInt64/r {
+		return time.Time{}, fmt.Errorf("%w: slot %d is not a representable time", ErrOverflow, slot)
+	}
+	return time.Unix(0, slot*r).UTC(), nil
+}
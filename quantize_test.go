@@ -0,0 +1,91 @@
+package gallifrey_test
+
+import (
+	"time"
+
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quantized time interval", func() {
+
+	var (
+		start, end time.Time
+		resolution time.Duration
+	)
+
+	BeforeEach(func() {
+		start = time.Unix(0, 0).Add(90 * time.Second)
+		end = time.Unix(0, 0).Add(5*time.Minute + 40*time.Second)
+		resolution = time.Minute
+	})
+
+	AssertQuantized := func(policy RoundPolicy, lower, upper int64) {
+		interval, err := NewQuantizedTimeInterval(start, end, resolution, policy)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(interval).Should(Equal(NewInterval(lower, upper)))
+	}
+
+	It("rounds both limits down", func() {
+		AssertQuantized(RoundFloor, 1, 5)
+	})
+
+	It("rounds both limits up", func() {
+		AssertQuantized(RoundCeil, 2, 6)
+	})
+
+	It("rounds both limits to the nearest slot", func() {
+		AssertQuantized(RoundNearest, 2, 6)
+	})
+
+	It("rounds outward to cover every slot touched", func() {
+		AssertQuantized(RoundOutward, 1, 6)
+	})
+
+	It("rounds inward to cover only whole slots", func() {
+		AssertQuantized(RoundInward, 2, 5)
+	})
+
+	It("leaves limits on slot boundaries alone", func() {
+		start = time.Unix(120, 0)
+		end = time.Unix(300, 0)
+		for _, policy := range []RoundPolicy{RoundFloor, RoundCeil, RoundNearest, RoundOutward, RoundInward} {
+			AssertQuantized(policy, 2, 5)
+		}
+	})
+
+	It("rounds times before the epoch towards negative infinity", func() {
+		start = time.Unix(-90, 0)
+		end = time.Unix(-30, 0)
+		AssertQuantized(RoundFloor, -2, -1)
+		AssertQuantized(RoundCeil, -1, 0)
+	})
+
+	It("gives an empty interval when no whole slot lies inside", func() {
+		start = time.Unix(70, 0)
+		end = time.Unix(110, 0)
+		AssertQuantized(RoundInward, 2, 2)
+	})
+
+	It("swaps limits given in reverse", func() {
+		start, end = end, start
+		AssertQuantized(RoundOutward, 1, 6)
+	})
+
+	It("rejects a resolution that is not positive", func() {
+		_, err := NewQuantizedTimeInterval(start, end, 0, RoundFloor)
+		Ω(err).Should(MatchError(ErrInvalidInterval))
+	})
+
+	It("rejects an unknown policy", func() {
+		_, err := NewQuantizedTimeInterval(start, end, resolution, RoundPolicy(42))
+		Ω(err).Should(MatchError(ErrInvalidInterval))
+	})
+
+	It("rejects times beyond the quantizable range", func() {
+		_, err := NewQuantizedTimeInterval(start, time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), resolution, RoundFloor)
+		Ω(err).Should(MatchError(ErrOverflow))
+	})
+})
@@ -0,0 +1,112 @@
+package gallifrey
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RawInterval is an interval as found in untrusted input, before its limits
+// have been parsed
+type RawInterval struct {
+	Lower, Upper string
+}
+
+// SanitizeOptions describes how Sanitize reads raw limits
+type SanitizeOptions struct {
+	// TimeLayouts are tried in order on limits that are not integers; times
+	// are converted to slots of Resolution counted from the Unix epoch,
+	// rounding outward
+	TimeLayouts []string
+	// Resolution is the size of a slot for times, a second if zero
+	Resolution time.Duration
+	// Sentinels are values, compared without regard to case, that stand for
+	// a missing limit. Empty values are always treated as missing.
+	Sentinels []string
+}
+
+// DefaultSentinels are the placeholders for missing values most often seen
+// in exported data
+var DefaultSentinels = []string{"NaN", "null", "nil", "none", "n/a", "-"}
+
+// Sanitize converts raw intervals into intervals, swapping reversed limits
+// and dropping exact duplicates, and keeping the order of the input
+// otherwise. A record that cannot be converted is left out, and an error
+// naming its index is returned for it: ErrInvalidInterval for missing or
+// unparseable limits, and ErrOverflow for limits outside Domain.
+func Sanitize(raw []RawInterval, opts SanitizeOptions) ([]Interval, []error) {
+	var (
+		intervals []Interval
+		errs      []error
+	)
+	seen := make(map[[2]int64]bool)
+	for n, r := range raw {
+		lowerFloor, lowerCeil, err := opts.limit(r.Lower)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("record %d: lower limit: %w", n, err))
+			continue
+		}
+		upperFloor, upperCeil, err := opts.limit(r.Upper)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("record %d: upper limit: %w", n, err))
+			continue
+		}
+		// Rounding outward from whichever limit turns out to be lower
+		l, u := lowerFloor, upperCeil
+		if upperFloor < l {
+			l = upperFloor
+		}
+		if lowerCeil > u {
+			u = lowerCeil
+		}
+		i, err := NewCheckedInterval(l, u)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("record %d: %w", n, err))
+			continue
+		}
+		key := [2]int64{i.Lower(), i.Upper()}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		intervals = append(intervals, i)
+	}
+	return intervals, errs
+}
+
+// limit parses one raw limit, returning the slots found by rounding it
+// down and up; these are the same unless it is a time between slots
+func (o SanitizeOptions) limit(value string) (int64, int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, 0, fmt.Errorf("%w: missing value", ErrInvalidInterval)
+	}
+	for _, sentinel := range o.Sentinels {
+		if strings.EqualFold(value, sentinel) {
+			return 0, 0, fmt.Errorf("%w: missing value %q", ErrInvalidInterval, value)
+		}
+	}
+	x, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		return x, x, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, 0, fmt.Errorf("%w: %s", ErrOverflow, value)
+	}
+	resolution := o.Resolution
+	if resolution == 0 {
+		resolution = time.Second
+	}
+	for _, layout := range o.TimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			slot, err := NewQuantizedTimeInterval(t, t, resolution, RoundOutward)
+			if err != nil {
+				return 0, 0, err
+			}
+			return slot.Lower(), slot.Upper(), nil
+		}
+	}
+	return 0, 0, fmt.Errorf("%w: cannot parse %q", ErrInvalidInterval, value)
+}
@@ -0,0 +1,73 @@
+package gallifrey_test
+
+import (
+	"time"
+
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sanitize", func() {
+
+	var opts SanitizeOptions
+
+	BeforeEach(func() {
+		opts = SanitizeOptions{
+			TimeLayouts: []string{time.RFC3339, "2006-01-02"},
+			Resolution:  time.Hour,
+			Sentinels:   DefaultSentinels,
+		}
+	})
+
+	It("keeps clean records in order", func() {
+		intervals, errs := Sanitize([]RawInterval{{"5", "10"}, {"0", "3"}}, opts)
+		Ω(errs).Should(BeEmpty())
+		Ω(intervals).Should(Equal([]Interval{NewInterval(5, 10), NewInterval(0, 3)}))
+	})
+
+	It("swaps reversed limits and drops duplicates", func() {
+		intervals, errs := Sanitize([]RawInterval{{"10", "5"}, {" 5", "10 "}, {"5", "10"}}, opts)
+		Ω(errs).Should(BeEmpty())
+		Ω(intervals).Should(Equal([]Interval{NewInterval(5, 10)}))
+	})
+
+	It("parses times in any of the layouts, rounding outward", func() {
+		intervals, errs := Sanitize([]RawInterval{
+			{"1970-01-01T01:30:00Z", "1970-01-01T03:10:00Z"},
+			{"1970-01-02", "1970-01-03"},
+			{"1970-01-01T03:10:00Z", "1970-01-01T01:30:00Z"},
+		}, opts)
+		Ω(errs).Should(BeEmpty())
+		Ω(intervals).Should(Equal([]Interval{NewInterval(1, 4), NewInterval(24, 48)}))
+	})
+
+	It("reports each bad record and keeps the rest", func() {
+		intervals, errs := Sanitize([]RawInterval{
+			{"NaN", "10"},
+			{"0", ""},
+			{"0", "yesterday"},
+			{"1", "2"},
+			{"0", "9223372036854775807"},
+			{"99999999999999999999", "0"},
+		}, opts)
+		Ω(intervals).Should(Equal([]Interval{NewInterval(1, 2)}))
+		Ω(errs).Should(HaveLen(5))
+		Ω(errs[0]).Should(MatchError(ErrInvalidInterval))
+		Ω(errs[0].Error()).Should(ContainSubstring("record 0"))
+		Ω(errs[1]).Should(MatchError(ErrInvalidInterval))
+		Ω(errs[2]).Should(MatchError(ErrInvalidInterval))
+		Ω(errs[2].Error()).Should(ContainSubstring("record 2"))
+		Ω(errs[3]).Should(MatchError(ErrOverflow))
+		Ω(errs[4]).Should(MatchError(ErrOverflow))
+		Ω(errs[4].Error()).Should(ContainSubstring("record 5: lower limit"))
+	})
+
+	It("only treats configured sentinels as missing", func() {
+		opts.Sentinels = nil
+		_, errs := Sanitize([]RawInterval{{"null", "1"}}, opts)
+		Ω(errs).Should(HaveLen(1))
+		Ω(errs[0].Error()).Should(ContainSubstring("cannot parse"))
+	})
+})
@@ -0,0 +1,171 @@
+package gallifrey
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Each part of a version is held in versionBits bits of its encoding, which
+// orders versions the same way as semantic versioning does
+const (
+	versionBits      = 20
+	versionPartLimit = 1 << versionBits
+	versionLimit     = 1 << (3 * versionBits)
+)
+
+// SemverSet is a set of semantic versions, held as intervals over an
+// encoding of major, minor and patch numbers. Pre-release and build
+// metadata are not supported.
+type SemverSet struct {
+	intervals []Interval
+}
+
+// ParseSemverSet parses constraints such as ">=1.2.0 <2.0.0 || >=3.1.0".
+// Comparators separated by spaces must all hold, and groups separated by
+// "||" are alternatives. Each comparator is a full version, optionally
+// preceded by one of =, <, <=, >, >=, ^ (same leftmost non-zero part) or ~
+// (same major and minor parts), with or without a space between them; "*"
+// matches every version. Malformed constraints fail with ErrInvalidInterval.
+func ParseSemverSet(constraints string) (*SemverSet, error) {
+	var alternatives [][]Interval
+	for _, group := range strings.Split(constraints, "||") {
+		comparators := strings.Fields(group)
+		if len(comparators) == 0 {
+			return nil, fmt.Errorf("%w: empty constraint in %q", ErrInvalidInterval, constraints)
+		}
+		all := []Interval{NewInterval(0, versionLimit)}
+		for n := 0; n < len(comparators); n++ {
+			c := comparators[n]
+			if strings.Trim(c, "=<>^~") == "" {
+				// An operator standing apart applies to the version after it
+				if n++; n == len(comparators) {
+					return nil, fmt.Errorf("%w: operator %q has no version in %q", ErrInvalidInterval, c, constraints)
+				}
+				c += comparators[n]
+			}
+			i, err := parseComparator(c)
+			if err != nil {
+				return nil, err
+			}
+			all = combine([][]Interval{all, {i}}, func(active []int) bool {
+				return len(active) == 2
+			})
+		}
+		alternatives = append(alternatives, all)
+	}
+	return &SemverSet{combine(alternatives, func(active []int) bool {
+		return len(active) > 0
+	})}, nil
+}
+
+func parseComparator(c string) (Interval, error) {
+	if c == "*" {
+		return NewInterval(0, versionLimit), nil
+	}
+	op := c[:len(c)-len(strings.TrimLeft(c, "=<>^~"))]
+	major, minor, patch, err := parseVersion(c[len(op):])
+	if err != nil {
+		return nil, err
+	}
+	v := encodeVersion(major, minor, patch)
+	switch op {
+	case "", "=":
+		return NewInterval(v, v+1), nil
+	case "<":
+		return NewInterval(0, v), nil
+	case "<=":
+		return NewInterval(0, v+1), nil
+	case ">":
+		return NewInterval(v+1, versionLimit), nil
+	case ">=":
+		return NewInterval(v, versionLimit), nil
+	case "^":
+		switch {
+		case major > 0:
+			return NewInterval(v, encodeVersion(major+1, 0, 0)), nil
+		case minor > 0:
+			return NewInterval(v, encodeVersion(0, minor+1, 0)), nil
+		}
+		return NewInterval(v, v+1), nil
+	case "~":
+		return NewInterval(v, encodeVersion(major, minor+1, 0)), nil
+	}
+	return nil, fmt.Errorf("%w: unknown operator in %q", ErrInvalidInterval, c)
+}
+
+// parseVersion reads a full version, optionally prefixed with "v", whose
+// parts are decimal numbers without signs or leading zeros
+func parseVersion(version string) (major, minor, patch int64, err error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("%w: %q is not a full version", ErrInvalidInterval, version)
+	}
+	var numbers [3]int64
+	for n, part := range parts {
+		if part == "" || strings.Trim(part, "0123456789") != "" || (part[0] == '0' && part != "0") {
+			return 0, 0, 0, fmt.Errorf("%w: %q is not a valid version", ErrInvalidInterval, version)
+		}
+		numbers[n], err = strconv.ParseInt(part, 10, 64)
+		if err != nil || numbers[n] >= versionPartLimit {
+			return 0, 0, 0, fmt.Errorf("%w: %q is not a supported version", ErrInvalidInterval, version)
+		}
+	}
+	return numbers[0], numbers[1], numbers[2], nil
+}
+
+// encodeVersion orders versions as int64s. A part reaching versionPartLimit
+// carries into the next, so the version after 1.2.x is 1.3.0.
+func encodeVersion(major, minor, patch int64) int64 {
+	return major<<(2*versionBits) + minor<<versionBits + patch
+}
+
+func decodeVersion(v int64) string {
+	const mask = versionPartLimit - 1
+	return fmt.Sprintf("%d.%d.%d", v>>(2*versionBits), v>>versionBits&mask, v&mask)
+}
+
+// Contains reports whether the set holds the given full version
+func (s *SemverSet) Contains(version string) (bool, error) {
+	major, minor, patch, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+	v := encodeVersion(major, minor, patch)
+	for _, i := range s.intervals {
+		if v >= i.Lower() && v < i.Upper() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Intersects reports whether some version lies in both sets
+func (s *SemverSet) Intersects(other *SemverSet) bool {
+	common := combine([][]Interval{s.intervals, other.intervals}, func(active []int) bool {
+		return len(active) == 2
+	})
+	return len(common) > 0
+}
+
+// String returns the set as a constraint that parses back to the same set
+func (s *SemverSet) String() string {
+	if len(s.intervals) == 0 {
+		return "<0.0.0"
+	}
+	groups := make([]string, len(s.intervals))
+	for n, i := range s.intervals {
+		var parts []string
+		if i.Lower() > 0 {
+			parts = append(parts, ">="+decodeVersion(i.Lower()))
+		}
+		if i.Upper() < versionLimit {
+			parts = append(parts, "<"+decodeVersion(i.Upper()))
+		}
+		if len(parts) == 0 {
+			parts = append(parts, "*")
+		}
+		groups[n] = strings.Join(parts, " ")
+	}
+	return strings.Join(groups, " || ")
+}
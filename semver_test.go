@@ -0,0 +1,77 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SemverSet", func() {
+
+	parse := func(constraints string) *SemverSet {
+		set, err := ParseSemverSet(constraints)
+		Ω(err).ShouldNot(HaveOccurred())
+		return set
+	}
+
+	AssertContains := func(constraints, version string, expected bool) {
+		It("checks "+version+" against "+constraints, func() {
+			contains, err := parse(constraints).Contains(version)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(contains).Should(Equal(expected))
+		})
+	}
+
+	AssertContains(">=1.2.0 <2.0.0 || >=3.1.0", "1.2.0", true)
+	AssertContains(">=1.2.0 <2.0.0 || >=3.1.0", "1.99.7", true)
+	AssertContains(">=1.2.0 <2.0.0 || >=3.1.0", "2.0.0", false)
+	AssertContains(">=1.2.0 <2.0.0 || >=3.1.0", "3.0.9", false)
+	AssertContains(">=1.2.0 <2.0.0 || >=3.1.0", "v42.0.0", true)
+	AssertContains(">1.2.3", "1.2.3", false)
+	AssertContains(">1.2.3", "1.2.4", true)
+	AssertContains("<=1.2.3", "1.2.3", true)
+	AssertContains("1.2.3", "1.2.3", true)
+	AssertContains("=1.2.3", "1.2.4", false)
+	AssertContains("^1.2.3", "1.9.0", true)
+	AssertContains("^1.2.3", "2.0.0", false)
+	AssertContains("^0.2.3", "0.3.0", false)
+	AssertContains("^0.0.3", "0.0.4", false)
+	AssertContains("~1.2.3", "1.2.9", true)
+	AssertContains("~1.2.3", "1.3.0", false)
+	AssertContains("*", "0.0.0", true)
+	AssertContains(">= 1.2.0 < 2.0.0", "1.5.0", true)
+	AssertContains(">= 1.2.0 < 2.0.0", "2.0.0", false)
+	AssertContains("^ 1.10.0", "1.10.0", true)
+
+	It("finds intersecting constraints", func() {
+		Ω(parse(">=1.0.0 <2.0.0").Intersects(parse("^1.9.0"))).Should(BeTrue())
+		Ω(parse(">=1.0.0 <2.0.0").Intersects(parse(">=2.0.0"))).Should(BeFalse())
+		Ω(parse(">=2.0.0 <1.0.0").Intersects(parse("*"))).Should(BeFalse())
+	})
+
+	It("writes the set as a normalized constraint", func() {
+		Ω(parse("^1.2.0 || >=1.5.0 <3.0.0 || >=4.0.0").String()).Should(Equal(">=1.2.0 <3.0.0 || >=4.0.0"))
+		Ω(parse("<1.0.0 || >=0.5.0").String()).Should(Equal("*"))
+		Ω(parse(">2.0.0 <1.0.0").String()).Should(Equal("<0.0.0"))
+	})
+
+	It("round trips through its string form", func() {
+		set := parse("~1.2.3 || =2.0.0 || >=5.0.0")
+		Ω(parse(set.String())).Should(Equal(set))
+	})
+
+	It("rejects malformed constraints", func() {
+		for _, constraints := range []string{"", ">=1.2", "1.2.x", "!1.0.0", ">=1.0.0 ||", "1.0.0-beta.1", "1.2.3.4", "01.2.3", "1.02.3", "+1.2.3", ">=-1.2.3", "1.2. 3", ">=", "<2.0.0 >="} {
+			_, err := ParseSemverSet(constraints)
+			Ω(err).Should(MatchError(ErrInvalidInterval))
+		}
+	})
+
+	It("rejects malformed versions", func() {
+		for _, version := range []string{"1.0", "1.00.0", "+1.0.0", "1.0.0x"} {
+			_, err := parse("*").Contains(version)
+			Ω(err).Should(MatchError(ErrInvalidInterval))
+		}
+	})
+})
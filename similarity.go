@@ -0,0 +1,62 @@
+package gallifrey
+
+// Metric is a way of scoring how alike two sets of covered values are
+type Metric int
+
+const (
+	// Jaccard divides the values covered by both sets by those covered by
+	// either
+	Jaccard Metric = iota
+	// OverlapCoefficient divides the values covered by both sets by those
+	// covered by the smaller set
+	OverlapCoefficient
+	// Dice divides twice the values covered by both sets by the sum of the
+	// values covered by each
+	Dice
+)
+
+// Similarity scores, between 0 and 1, how alike the coverage of a and b is
+// under the given metric. Each may be unsorted and overlap itself. Two
+// empty sets score 1; an empty set scores 0 against any other. An unknown
+// metric scores 0.
+func Similarity(a, b []Interval, metric Metric) float64 {
+	var inA, inB, both, either float64
+	var from int64
+	var active []int
+	Sweep([][]Interval{a, b}, func(x int64, now []int) {
+		span := float64(x - from)
+		for _, c := range active {
+			if c == 0 {
+				inA += span
+			} else {
+				inB += span
+			}
+		}
+		if len(active) == 2 {
+			both += span
+		}
+		if len(active) > 0 {
+			either += span
+		}
+		from, active = x, append(active[:0], now...)
+	})
+	if either == 0 {
+		return 1
+	}
+	switch metric {
+	case Jaccard:
+		return both / either
+	case OverlapCoefficient:
+		smaller := inA
+		if inB < smaller {
+			smaller = inB
+		}
+		if smaller == 0 {
+			return 0
+		}
+		return both / smaller
+	case Dice:
+		return 2 * both / (inA + inB)
+	}
+	return 0
+}
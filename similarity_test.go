@@ -0,0 +1,40 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Similarity", func() {
+
+	a := []Interval{NewInterval(0, 10), NewInterval(20, 30)}
+	b := []Interval{NewInterval(5, 15), NewInterval(5, 10)}
+
+	It("scores shared coverage under each metric", func() {
+		Ω(Similarity(a, b, Jaccard)).Should(BeNumerically("~", 5.0/25))
+		Ω(Similarity(a, b, OverlapCoefficient)).Should(BeNumerically("~", 5.0/10))
+		Ω(Similarity(a, b, Dice)).Should(BeNumerically("~", 10.0/30))
+	})
+
+	It("is symmetric", func() {
+		for _, metric := range []Metric{Jaccard, OverlapCoefficient, Dice} {
+			Ω(Similarity(b, a, metric)).Should(Equal(Similarity(a, b, metric)))
+		}
+	})
+
+	It("scores identical coverage as 1 however it is split", func() {
+		split := []Interval{NewInterval(20, 30), NewInterval(0, 4), NewInterval(4, 10)}
+		for _, metric := range []Metric{Jaccard, OverlapCoefficient, Dice} {
+			Ω(Similarity(a, split, metric)).Should(Equal(1.0))
+		}
+	})
+
+	It("scores disjoint and empty coverage", func() {
+		Ω(Similarity(a, []Interval{NewInterval(10, 20)}, Jaccard)).Should(BeZero())
+		Ω(Similarity(a, nil, Dice)).Should(BeZero())
+		Ω(Similarity(nil, a, OverlapCoefficient)).Should(BeZero())
+		Ω(Similarity(nil, nil, Jaccard)).Should(Equal(1.0))
+	})
+})
@@ -0,0 +1,43 @@
+package gallifrey
+
+// Sketch is an approximate summary of coverage in a bounded number of
+// intervals. It covers everything the intervals it was built from cover, and
+// at most ErrorBound more.
+type Sketch struct {
+	intervals  []Interval
+	errorBound int64
+}
+
+// NewSketch summarizes the coverage of intervals, which may be unsorted and
+// overlap, in at most maxRanges intervals. The smallest gaps in the coverage
+// are closed first, so the sketch covers as little extra as it can. A
+// maxRanges below one is treated as one.
+func NewSketch(intervals []Interval, maxRanges int) *Sketch {
+	merged := combine([][]Interval{intervals}, func(active []int) bool {
+		return len(active) > 0
+	})
+	cover, extra := minimalCover(merged, maxRanges)
+	return &Sketch{cover, extra}
+}
+
+// Intervals returns the sketch's intervals in ascending order
+func (s *Sketch) Intervals() []Interval {
+	return append([]Interval(nil), s.intervals...)
+}
+
+// ErrorBound returns how much the sketch covers beyond the intervals it was
+// built from, the total span of the gaps it closed
+func (s *Sketch) ErrorBound() int64 {
+	return s.errorBound
+}
+
+// Contains reports whether the sketch covers x. It never misses a value the
+// original intervals cover, but may report values in a closed gap.
+func (s *Sketch) Contains(x int64) bool {
+	for _, i := range s.intervals {
+		if x >= i.Lower() && x < i.Upper() {
+			return true
+		}
+	}
+	return false
+}
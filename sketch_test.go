@@ -0,0 +1,57 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sketch", func() {
+
+	intervals := []Interval{
+		NewInterval(50, 60),
+		NewInterval(0, 10),
+		NewInterval(5, 12),
+		NewInterval(14, 20),
+		NewInterval(30, 40),
+		NewInterval(7, 7),
+	}
+
+	It("keeps exact coverage when it fits", func() {
+		sketch := NewSketch(intervals, 4)
+		Ω(sketch.Intervals()).Should(Equal([]Interval{
+			NewInterval(0, 12),
+			NewInterval(14, 20),
+			NewInterval(30, 40),
+			NewInterval(50, 60),
+		}))
+		Ω(sketch.ErrorBound()).Should(BeZero())
+	})
+
+	It("closes the smallest gaps and bounds the error by their span", func() {
+		sketch := NewSketch(intervals, 2)
+		Ω(sketch.Intervals()).Should(Equal([]Interval{
+			NewInterval(0, 40),
+			NewInterval(50, 60),
+		}))
+		Ω(sketch.ErrorBound()).Should(Equal(int64(12)))
+	})
+
+	It("covers every value the intervals cover", func() {
+		sketch := NewSketch(intervals, 1)
+		for _, i := range intervals {
+			for x := i.Lower(); x < i.Upper(); x++ {
+				Ω(sketch.Contains(x)).Should(BeTrue())
+			}
+		}
+		Ω(sketch.Contains(60)).Should(BeFalse())
+		Ω(sketch.ErrorBound()).Should(Equal(int64(22)))
+	})
+
+	It("summarizes no intervals as empty", func() {
+		sketch := NewSketch(nil, 3)
+		Ω(sketch.Intervals()).Should(BeEmpty())
+		Ω(sketch.ErrorBound()).Should(BeZero())
+	})
+})
@@ -0,0 +1,110 @@
+package gallifrey
+
+import "sort"
+
+type sweepEvent struct {
+	x          int64
+	collection int
+	delta      int
+}
+
+// Sweep walks the limits of every interval in the given collections in
+// ascending order. At each distinct limit x it calls visit with the indices,
+// in ascending order, of the collections that cover x and everything up to
+// the next limit. Intervals are taken to include their lower limit but not
+// their upper one, so intervals of zero span cover nothing and are ignored.
+// The active slice is reused between calls and must not be retained.
+func Sweep(collections [][]Interval, visit func(x int64, active []int)) {
+	var events []sweepEvent
+	for c, intervals := range collections {
+		for _, i := range intervals {
+			if i.Lower() == i.Upper() {
+				continue
+			}
+			events = append(events, sweepEvent{i.Lower(), c, 1}, sweepEvent{i.Upper(), c, -1})
+		}
+	}
+	sort.Slice(events, func(a, b int) bool {
+		return events[a].x < events[b].x
+	})
+	counts := make([]int, len(collections))
+	active := make([]int, 0, len(collections))
+	for e := 0; e < len(events); {
+		x := events[e].x
+		for ; e < len(events) && events[e].x == x; e++ {
+			counts[events[e].collection] += events[e].delta
+		}
+		active = active[:0]
+		for c, n := range counts {
+			if n > 0 {
+				active = append(active, c)
+			}
+		}
+		visit(x, active)
+	}
+}
+
+// PeakOverlap returns the greatest number of the given intervals that cover
+// any single point, and the first maximal interval over which that many are
+// active throughout. Overlapping intervals are counted separately rather than merged. If no
+// interval has a positive span, the count is zero and the interval is nil.
+func PeakOverlap(intervals []Interval) (count int, at Interval) {
+	var events []sweepEvent
+	for _, i := range intervals {
+		if i.Lower() == i.Upper() {
+			continue
+		}
+		events = append(events, sweepEvent{i.Lower(), 0, 1}, sweepEvent{i.Upper(), 0, -1})
+	}
+	sort.Slice(events, func(a, b int) bool {
+		return events[a].x < events[b].x
+	})
+	n := 0
+	var from int64
+	peak := false
+	for e := 0; e < len(events); {
+		x := events[e].x
+		for ; e < len(events) && events[e].x == x; e++ {
+			n += events[e].delta
+		}
+		switch {
+		case n > count:
+			// Coverage is positive, so a closing event is still to come
+			count, from, peak = n, x, true
+		case n < count:
+			peak = false
+		}
+		if peak {
+			at = NewInterval(from, events[e].x)
+		}
+	}
+	return
+}
+
+// Quorum returns, sorted and merged, the stretches covered by at least k of
+// the given collections. Overlaps within one collection count once, and a k
+// below one is treated as one.
+func Quorum(collections [][]Interval, k int) []Interval {
+	return combine(collections, func(active []int) bool {
+		return len(active) > 0 && len(active) >= k
+	})
+}
+
+// combine returns, sorted and merged, the stretches over which keep holds
+// for the indices of the collections covering them
+func combine(collections [][]Interval, keep func(active []int) bool) []Interval {
+	var result []Interval
+	var from int64
+	kept := false
+	Sweep(collections, func(x int64, active []int) {
+		if kept {
+			if n := len(result) - 1; n >= 0 && result[n].Upper() == from {
+				result[n] = NewInterval(result[n].Lower(), x)
+			} else {
+				result = append(result, NewInterval(from, x))
+			}
+		}
+		from, kept = x, keep(active)
+	})
+	return result
+}
@@ -0,0 +1,149 @@
+package gallifrey_test
+
+import (
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sweep", func() {
+
+	type step struct {
+		x      int64
+		active []int
+	}
+
+	var steps []step
+
+	sweep := func(collections ...[]Interval) {
+		steps = nil
+		Sweep(collections, func(x int64, active []int) {
+			steps = append(steps, step{x, append([]int{}, active...)})
+		})
+	}
+
+	It("visits nothing without intervals", func() {
+		sweep()
+		Ω(steps).Should(BeEmpty())
+	})
+
+	It("reports a single collection", func() {
+		sweep([]Interval{NewInterval(0, 5), NewInterval(10, 15)})
+		Ω(steps).Should(Equal([]step{
+			{0, []int{0}},
+			{5, []int{}},
+			{10, []int{0}},
+			{15, []int{}},
+		}))
+	})
+
+	It("reports which collections are active", func() {
+		sweep(
+			[]Interval{NewInterval(0, 10)},
+			[]Interval{NewInterval(5, 15)},
+			[]Interval{NewInterval(10, 20)},
+		)
+		Ω(steps).Should(Equal([]step{
+			{0, []int{0}},
+			{5, []int{0, 1}},
+			{10, []int{1, 2}},
+			{15, []int{2}},
+			{20, []int{}},
+		}))
+	})
+
+	It("keeps a collection active across its own overlapping intervals", func() {
+		sweep([]Interval{NewInterval(0, 10), NewInterval(5, 15)})
+		Ω(steps).Should(Equal([]step{
+			{0, []int{0}},
+			{5, []int{0}},
+			{10, []int{0}},
+			{15, []int{}},
+		}))
+	})
+
+	It("ignores intervals of zero span", func() {
+		sweep([]Interval{NewInterval(3, 3)})
+		Ω(steps).Should(BeEmpty())
+	})
+})
+
+var _ = Describe("PeakOverlap", func() {
+
+	It("finds nothing without intervals", func() {
+		count, at := PeakOverlap(nil)
+		Ω(count).Should(BeZero())
+		Ω(at).Should(BeNil())
+	})
+
+	It("counts a single interval", func() {
+		count, at := PeakOverlap([]Interval{NewInterval(3, 7)})
+		Ω(count).Should(Equal(1))
+		Ω(at).Should(Equal(NewInterval(3, 7)))
+	})
+
+	It("finds where most intervals overlap", func() {
+		count, at := PeakOverlap([]Interval{
+			NewInterval(0, 10),
+			NewInterval(2, 6),
+			NewInterval(4, 8),
+			NewInterval(12, 20),
+		})
+		Ω(count).Should(Equal(3))
+		Ω(at).Should(Equal(NewInterval(4, 6)))
+	})
+
+	It("counts identical intervals separately", func() {
+		count, _ := PeakOverlap([]Interval{NewInterval(0, 5), NewInterval(0, 5)})
+		Ω(count).Should(Equal(2))
+	})
+
+	It("does not count intervals that only meet", func() {
+		count, at := PeakOverlap([]Interval{NewInterval(0, 5), NewInterval(5, 10)})
+		Ω(count).Should(Equal(1))
+		Ω(at).Should(Equal(NewInterval(0, 10)))
+	})
+
+	It("finds the whole stretch at the peak across changes of interval", func() {
+		count, at := PeakOverlap([]Interval{
+			NewInterval(0, 10),
+			NewInterval(0, 5),
+			NewInterval(5, 10),
+			NewInterval(12, 14),
+			NewInterval(12, 14),
+		})
+		Ω(count).Should(Equal(2))
+		Ω(at).Should(Equal(NewInterval(0, 10)))
+	})
+})
+
+var _ = Describe("Quorum", func() {
+
+	var collectors [][]Interval
+
+	BeforeEach(func() {
+		collectors = [][]Interval{
+			{NewInterval(0, 10), NewInterval(20, 30)},
+			{NewInterval(5, 25)},
+			{NewInterval(8, 12), NewInterval(9, 22)},
+		}
+	})
+
+	It("finds stretches seen by at least two collections", func() {
+		Ω(Quorum(collectors, 2)).Should(Equal([]Interval{NewInterval(5, 25)}))
+	})
+
+	It("finds stretches seen by every collection", func() {
+		Ω(Quorum(collectors, 3)).Should(Equal([]Interval{NewInterval(8, 10), NewInterval(20, 22)}))
+	})
+
+	It("merges everything seen by any collection", func() {
+		Ω(Quorum(collectors, 1)).Should(Equal([]Interval{NewInterval(0, 30)}))
+		Ω(Quorum(collectors, 0)).Should(Equal(Quorum(collectors, 1)))
+	})
+
+	It("finds nothing when the quorum cannot be met", func() {
+		Ω(Quorum(collectors, 4)).Should(BeEmpty())
+	})
+})
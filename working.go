@@ -0,0 +1,99 @@
+package gallifrey
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// AddWorking returns the time reached by advancing t by d of working time,
+// counting only the time inside the working intervals and skipping the gaps
+// between them. The working intervals are in slots of resolution counted
+// from the Unix epoch, and must be sorted and not overlap. Work that ends
+// exactly as a working interval does ends there rather than at the start of
+// the next one, and a d of zero gives t itself. It fails with ErrNotCovered
+// if the working intervals end before d has been worked, and with
+// ErrInvalidInterval for a negative d or a resolution that is not positive.
+func AddWorking(working []Interval, resolution time.Duration, t time.Time, d time.Duration) (time.Time, error) {
+	if resolution <= 0 {
+		return time.Time{}, fmt.Errorf("%w: resolution %v is not positive", ErrInvalidInterval, resolution)
+	}
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("%w: negative working time %v", ErrInvalidInterval, d)
+	}
+	if t.Before(minQuantizable) || t.After(maxQuantizable) {
+		return time.Time{}, fmt.Errorf("%w: %v is outside the quantizable range", ErrOverflow, t)
+	}
+	if d == 0 {
+		return t, nil
+	}
+	r := int64(resolution)
+	pos, rem := t.UnixNano(), int64(d)
+	w := sort.Search(len(working), func(n int) bool {
+		return slotNanos(working[n].Upper(), r) > pos
+	})
+	for ; w < len(working); w++ {
+		l, u := slotNanos(working[w].Lower(), r), slotNanos(working[w].Upper(), r)
+		if l > pos {
+			pos = l
+		}
+		if (pos <= 0 || rem <= math.MaxInt64-pos) && pos+rem <= u {
+			return time.Unix(0, pos+rem).In(t.Location()), nil
+		}
+		rem -= u - pos
+	}
+	return time.Time{}, fmt.Errorf("%w: %v of working time remain after %v", ErrNotCovered, time.Duration(rem), t)
+}
+
+// ElapsedCovered returns how much of the time from a to b lies inside the
+// covered intervals, the inverse of AddWorking. The result is negative if b
+// is before a. The covered intervals are in slots of resolution counted
+// from the Unix epoch, and must be sorted and not overlap.
+func ElapsedCovered(covered []Interval, resolution time.Duration, a, b time.Time) (time.Duration, error) {
+	if resolution <= 0 {
+		return 0, fmt.Errorf("%w: resolution %v is not positive", ErrInvalidInterval, resolution)
+	}
+	sign := time.Duration(1)
+	if b.Before(a) {
+		a, b, sign = b, a, -1
+	}
+	if a.Before(minQuantizable) || b.After(maxQuantizable) {
+		return 0, fmt.Errorf("%w: %v to %v is outside the quantizable range", ErrOverflow, a, b)
+	}
+	r := int64(resolution)
+	from, to := a.UnixNano(), b.UnixNano()
+	c := sort.Search(len(covered), func(n int) bool {
+		return slotNanos(covered[n].Upper(), r) > from
+	})
+	var total int64
+	for ; c < len(covered); c++ {
+		l, u := slotNanos(covered[c].Lower(), r), slotNanos(covered[c].Upper(), r)
+		if l >= to {
+			break
+		}
+		if l < from {
+			l = from
+		}
+		if u > to {
+			u = to
+		}
+		if (l < 0 && u > math.MaxInt64+l) || u-l > math.MaxInt64-total {
+			return 0, fmt.Errorf("%w: covered time from %v to %v", ErrOverflow, a, b)
+		}
+		total += u - l
+	}
+	return sign * time.Duration(total), nil
+}
+
+// slotNanos returns the nanoseconds since the Unix epoch at which the given
+// slot starts, saturating at the limits of int64
+func slotNanos(slot, resolution int64) int64 {
+	switch {
+	case slot > math.MaxInt64/resolution:
+		return math.MaxInt64
+	case slot < math.MinInt64/resolution:
+		return math.MinInt64
+	}
+	return slot * resolution
+}
@@ -0,0 +1,86 @@
+package gallifrey_test
+
+import (
+	"time"
+
+	. "github.com/ghostlang/gallifrey"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Working time", func() {
+
+	// Nine to five on the first two days after the epoch, in hours
+	working := []Interval{NewInterval(9, 17), NewInterval(33, 41)}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(1970, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	Context("adding working time", func() {
+
+		AssertAdded := func(from time.Time, d time.Duration, expected time.Time) {
+			to, err := AddWorking(working, time.Hour, from, d)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(to).Should(Equal(expected))
+		}
+
+		It("adds time within a working interval", func() {
+			AssertAdded(at(1, 9, 30), 30*time.Minute, at(1, 10, 0))
+		})
+
+		It("starts counting at the next working interval", func() {
+			AssertAdded(at(1, 6, 0), 8*time.Hour, at(1, 17, 0))
+		})
+
+		It("skips the gaps between working intervals", func() {
+			AssertAdded(at(1, 16, 0), 2*time.Hour, at(2, 10, 0))
+			AssertAdded(at(1, 17, 0), time.Hour, at(2, 10, 0))
+		})
+
+		It("adds nothing for no working time", func() {
+			AssertAdded(at(1, 20, 0), 0, at(1, 20, 0))
+		})
+
+		It("fails once the working intervals run out", func() {
+			_, err := AddWorking(working, time.Hour, at(2, 12, 0), 6*time.Hour)
+			Ω(err).Should(MatchError(ErrNotCovered))
+		})
+
+		It("rejects negative working time", func() {
+			_, err := AddWorking(working, time.Hour, at(1, 9, 0), -time.Hour)
+			Ω(err).Should(MatchError(ErrInvalidInterval))
+		})
+	})
+
+	Context("measuring covered time", func() {
+
+		AssertElapsed := func(from, to time.Time, expected time.Duration) {
+			elapsed, err := ElapsedCovered(working, time.Hour, from, to)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(elapsed).Should(Equal(expected))
+		}
+
+		It("counts only the covered time between two instants", func() {
+			AssertElapsed(at(1, 9, 30), at(1, 10, 0), 30*time.Minute)
+			AssertElapsed(at(1, 0, 0), at(3, 0, 0), 16*time.Hour)
+			AssertElapsed(at(1, 16, 0), at(2, 10, 0), 2*time.Hour)
+		})
+
+		It("counts nothing within a gap", func() {
+			AssertElapsed(at(1, 18, 0), at(2, 8, 0), 0)
+		})
+
+		It("is negative when the instants are reversed", func() {
+			AssertElapsed(at(2, 10, 0), at(1, 16, 0), -2*time.Hour)
+		})
+
+		It("inverts adding working time", func() {
+			from := at(1, 11, 15)
+			to, err := AddWorking(working, time.Hour, from, 9*time.Hour)
+			Ω(err).ShouldNot(HaveOccurred())
+			AssertElapsed(from, to, 9*time.Hour)
+		})
+	})
+})